@@ -14,6 +14,11 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/cmd"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
@@ -21,7 +26,18 @@ import (
 
 func main() {
 	config.Init("")
+
+	// Cancel the command on interrupt, a second interrupt exits immediately
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+		util.PrintNotify("Cancelling, interrupt again to exit immediately")
+	}()
+
 	rootCmd := cmd.NewRootCommand()
-	err := rootCmd.Execute()
+	err := rootCmd.ExecuteContext(ctx)
+	util.CloseSSHConnections()
+	util.CloseAuditLog()
 	util.Check(err)
 }
@@ -14,6 +14,7 @@
 package attachagent
 
 import (
+	"context"
 	"errors"
 	"fmt"
 
@@ -67,7 +68,7 @@ func (exe *executor) fail(inErr error) error {
 	return inErr
 }
 
-func (exe *executor) Execute() error {
+func (exe *executor) Execute(ctx context.Context) error {
 	util.SpinStart("Attaching Agent")
 
 	// Update local cache based on Controller
@@ -92,7 +93,7 @@ func (exe *executor) Execute() error {
 		exe.opt.Name,
 		agentConfig,
 		exe.opt.Namespace, nil)
-	if err := configExecutor.Execute(); err != nil {
+	if err := configExecutor.Execute(ctx); err != nil {
 		return err
 	}
 
@@ -115,13 +116,13 @@ func (exe *executor) Execute() error {
 		msg := "attach: Could not convert Executor"
 		return exe.fail(errors.New(msg))
 	}
-	UUID, err := deployExecutor.ProvisionAgent()
+	UUID, err := deployExecutor.ProvisionAgent(ctx)
 	if err != nil {
 		return exe.fail(err)
 	}
 	// TODO: Remove this additional config deploy step when Agent no longer posts config on provision
 	// Deploy config again
-	if err := configExecutor.Execute(); err != nil {
+	if err := configExecutor.Execute(ctx); err != nil {
 		return err
 	}
 
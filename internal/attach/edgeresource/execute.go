@@ -14,6 +14,7 @@
 package attachedgeresource
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
@@ -41,7 +42,7 @@ func (exe executor) GetName() string {
 	return fmt.Sprintf("%s/%s", exe.Name, exe.Version)
 }
 
-func (exe executor) Execute() error {
+func (exe executor) Execute(ctx context.Context) error {
 	util.SpinStart("Attaching Edge Resource")
 
 	// Init client
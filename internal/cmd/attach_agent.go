@@ -38,7 +38,7 @@ The Agent will be provisioned with the Controller within the Namespace.`,
 
 			// Run the command
 			exe := attach.NewExecutor(&opt)
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			util.PrintSuccess("Successfully attached Agent " + opt.Name + " to namespace " + opt.Namespace)
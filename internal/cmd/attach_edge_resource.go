@@ -40,7 +40,7 @@ func newAttachEdgeResourceCommand() *cobra.Command {
 
 			// Run the command
 			exe := attach.NewExecutor(opt)
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			msg := fmt.Sprintf("Successfully attached EdgeResource %s/%s to Agent %s", opt.Name, opt.Version, opt.Agent)
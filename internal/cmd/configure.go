@@ -30,7 +30,10 @@ func newConfigureCommand() *cobra.Command {
 		Short: "Configure iofogctl or ioFog resources",
 		Long: `Configure iofogctl or ioFog resources
 
-If you would like to replace the host value of Remote Controllers or Agents, you should delete and redeploy those resources.`,
+If you would like to replace the host value of Remote Controllers or Agents, you should delete and redeploy those resources.
+
+The user, port, key and jump hosts which are not configured are read from the entry of the host in ~/.ssh/config,
+whose Host aliases can be used as host values.`,
 		Example: `iofogctl configure current-namespace NAME
 
 iofogctl configure controller  NAME --user USER --key KEYFILE --port PORTNUM
@@ -65,7 +68,7 @@ iofogctl configure controlplane --kube FILE`,
 			util.Check(err)
 
 			// Execute the command
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			util.PrintSuccess(fmt.Sprintf("Succesfully configured %s %s", opt.ResourceType, opt.Name))
@@ -43,7 +43,7 @@ iofogctl connect --generate`,
 			opt.Namespace, err = cmd.Flags().GetString("namespace")
 			util.Check(err)
 			// Execute command
-			err = connect.Execute(&opt)
+			err = connect.Execute(cmd.Context(), &opt)
 			util.Check(err)
 
 			if !opt.Generate {
@@ -52,7 +52,7 @@ iofogctl connect --generate`,
 		},
 	}
 	// Register flags
-	cmd.Flags().StringVarP(&opt.InputFile, "file", "f", "", pkg.flagDescYaml)
+	addInputFlags(cmd, &opt.Input)
 	cmd.Flags().StringVar(&opt.ControllerName, "name", "", "Name you would like to assign to Controller")
 	cmd.Flags().StringVar(&opt.ControllerEndpoint, "ecn-addr", "", "URL of Edge Compute Network to connect to")
 	cmd.Flags().StringVar(&opt.KubeConfig, "kube", "", "Kubernetes config file. Typically ~/.kube/config")
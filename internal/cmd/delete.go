@@ -28,20 +28,22 @@ func newDeleteCommand() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "delete",
 		Short: "Delete an existing ioFog resource",
-		Long:  `Delete an existing ioFog resource.`,
-		Args:  cobra.ExactArgs(0),
+		Long: `Delete an existing ioFog resource.
+
+Documents of other kinds are deleted first by their iofogctl-kind-<kind> plugin of PATH, run with the delete argument, see the deploy command.`,
+		Args: cobra.ExactArgs(0),
 		Run: func(cmd *cobra.Command, args []string) {
 			var err error
 			opt.Namespace, err = cmd.Flags().GetString("namespace")
 			util.Check(err)
 
 			// Check file
-			if opt.InputFile == "" {
-				util.Check(errors.New("provided empty value for input file via the -f flag"))
+			if opt.Input.IsEmpty() {
+				util.Check(errors.New("provided no input file via the -f flag nor overlay via the -k flag"))
 			}
 
 			// Execute command
-			err = delete.Execute(opt)
+			err = delete.Execute(cmd.Context(), opt)
 			util.Check(err)
 
 			util.PrintSuccess("Successfully deleted resources from namespace " + opt.Namespace)
@@ -65,7 +67,7 @@ func newDeleteCommand() *cobra.Command {
 	)
 
 	// Register flags
-	cmd.Flags().StringVarP(&opt.InputFile, "file", "f", "", pkg.flagDescYaml)
+	addInputFlags(cmd, &opt.Input)
 
 	return cmd
 }
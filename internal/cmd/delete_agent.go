@@ -44,7 +44,7 @@ If you wish to not remove the Agent stack from the host, please use iofogctl det
 			// Run the command
 			exe, err := delete.NewExecutor(namespace, name, useDetached, force)
 			util.Check(err)
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			printName := name
@@ -36,7 +36,7 @@ If you don't want to tear down the deployments but would like to free up the Nam
 			util.Check(err)
 			useDetached, err := cmd.Flags().GetBool("detached")
 			util.Check(err)
-			err = delete.Execute(namespace, useDetached, force)
+			err = delete.Execute(cmd.Context(), namespace, useDetached, force)
 			util.Check(err)
 
 			util.PrintSuccess("Successfully deleted all resources in namespace " + namespace)
@@ -33,7 +33,7 @@ func newDeleteApplicationCommand() *cobra.Command {
 			util.Check(err)
 
 			// Execute command
-			err = delete.Execute(namespace, name)
+			err = delete.Execute(cmd.Context(), namespace, name)
 			util.Check(err)
 
 			util.PrintSuccess("Successfully deleted " + namespace + "/" + name)
@@ -35,7 +35,7 @@ func newDeleteControllerCommand() *cobra.Command {
 			// Get an executor for the command
 			exe, err := delete.NewExecutor(namespace, name)
 			util.Check(err)
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			util.PrintSuccess("Successfully deleted " + namespace + "/" + name)
@@ -40,7 +40,7 @@ Agents that this Edge Resource are attached to will be notified of the deletion.
 
 			// Run the command
 			exe := delete.NewExecutor(namespace, name, version)
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			msg := fmt.Sprintf("Successfully deleted %s/%s", name, version)
@@ -36,7 +36,7 @@ If you would like to delete all resources in the Namespace, use the --force flag
 			name := args[0]
 
 			// Execute command
-			err := delete.Execute(name, force)
+			err := delete.Execute(cmd.Context(), name, force)
 			util.Check(err)
 
 			util.PrintSuccess("Successfully deleted Namespace " + name)
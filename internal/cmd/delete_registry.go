@@ -35,7 +35,7 @@ func newDeleteRegistryCommand() *cobra.Command {
 			// Get an executor for the command
 			exe, err := delete.NewExecutor(namespace, id)
 			util.Check(err)
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			util.PrintSuccess("Successfully deleted registry " + id)
@@ -36,7 +36,7 @@ The corresponding Microservices will no longer be able to reach each other using
 
 			// Run the command
 			exe := delete.NewExecutor(namespace, name)
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			util.PrintSuccess("Successfully deleted " + namespace + "/" + name)
@@ -37,7 +37,7 @@ The Volume will be deleted from the Agents that it is stored on.`,
 			// Run the command
 			exe, err := delete.NewExecutor(namespace, name)
 			util.Check(err)
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			util.PrintSuccess("Successfully deleted " + namespace + "/" + name)
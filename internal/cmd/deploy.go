@@ -15,8 +15,10 @@ package cmd
 
 import (
 	"errors"
+	"time"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/deploy"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/wait"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 	"github.com/spf13/cobra"
 )
@@ -35,31 +37,122 @@ func newDeployCommand() *cobra.Command {
           edge-resource.yaml
           catalog.yaml
           volume.yaml
-          route.yaml`,
+          route.yaml
+
+deploy -f agents/ -f apps/ -f routes/ -R
+
+deploy -f 'apps/*.yaml'
+
+deploy -f - < ecn.yaml
+
+deploy -f application.yaml --values prod.yaml --set image.tag=1.2.0
+
+deploy -k overlays/prod
+
+deploy -f dev/ -f prod/
+
+deploy -f ecn.yaml --dry-run
+
+deploy -f ecn.yaml --prune
+
+deploy -f ecn.yaml --atomic
+
+deploy -f ecn.yaml --continue-on-error --failed-manifest failed.yaml
+
+deploy -f application.yaml --wait --wait-timeout 10m
+
+deploy -f ecn.yaml --parallelism 10 --parallelism-per-kind Microservice=2 --retries 3 --retries-per-kind Agent=5`,
 		Args:  cobra.ExactArgs(0),
 		Short: "Deploy Edge Compute Network components on existing infrastructure",
 		Long: `Deploy Edge Compute Network components on existing infrastructure.
-Visit iofog.org to view all YAML specifications usable with this command.`,
+Visit iofog.org to view all YAML specifications usable with this command.
+
+The -f flag can be repeated and accepts files, directories, globs, and - to read from stdin.
+The .yaml and .yml files of a directory are read, and -R reads those of its subdirectories too.
+The documents of all files are deployed together.
+Each document is deployed to the Namespace of its metadata.namespace field, or to the Namespace of the --namespace flag if it has none.
+The Namespaces are deployed one after the other, in the order of their first document. Each Namespace must already exist, see the create namespace command.
+The ${key} references of the files are substituted with the values of --set, --values files and, with --env, environment variables, see the render command.
+
+Use -k to deploy an overlay, a directory containing an overlay.yaml file which lists resources and patches:
+  resources:            # YAML files, directories, globs or other overlays, relative to the overlay
+  - ../base
+  patches:
+  - path: agents.yaml   # Documents merged into the resources of the same kind and metadata.name
+  - target:             # JSON patch applied to the resource of the kind and name
+      kind: Application
+      name: app
+    patch: |
+      - op: replace
+        path: /spec/microservices/0/images/x86
+        value: iofog/app:1.2.0
+Merged maps are merged recursively and null values delete their key. Lists of maps are merged by their name or key field,
+and their items with $patch: delete are removed. Other lists are replaced.
+
+Use --dry-run to print the resources that would be created, updated, left unchanged or deleted without deploying anything.
+
+Use --prune to delete the Applications, Routes, Volumes, Registries and Edge Resources of the Namespace which are not declared in the YAML file.
+The Application of a Microservice or Route document is declared along with it.
+The resources to delete are listed for confirmation before anything is deployed, unless --yes is provided.
+
+Use --atomic to roll back the changes if the deployment fails. Created resources are deleted and updated or pruned resources are restored to their previous spec.
+Control Planes, Controllers, Agents and Catalog Items are not rolled back.
+Only the changes of the Namespace which failed are rolled back, and the following Namespaces are not deployed.
+
+A report of each deployed resource is printed once the deployment ends.
+Use --continue-on-error to keep deploying the resources which do not depend on a failed one,
+and --failed-manifest to write the documents which failed or were skipped to a file which can be deployed again.
+
+Use --parallelism to limit the number of resources deployed at once, and --parallelism-per-kind to limit each kind further.
+Use --retries to deploy a resource again when it fails with a transient error, such as a network failure or a 5xx response from the Controller.
+Retries are delayed by --retry-backoff, which doubles after each retry. Use --retries-per-kind to override the number of retries of each kind.
+
+A revision of each Application deployed with a different spec is recorded, see the history and rollback commands.
+
+Use --wait to wait until the microservices of the deployed Applications and Microservices are RUNNING.
+The command fails with the status of each microservice if any of them is FAILED, or if --wait-timeout or --timeout expires first.
+--wait-timeout only bounds the wait, while --timeout also cancels the deployment.
+
+Documents of other kinds are deployed by plugins. The iofogctl-kind-<kind> executable of PATH, e.g. iofogctl-kind-widget for the Widget kind,
+is run with the deploy argument and reads a JSON object from its standard input with the verb, the document,
+and the name, Controller endpoint, user and access token of its Namespace. Its error output is reported if it exits with an error.
+With --dry-run, it is run with the plan argument instead and must write {"action": "create|update|no-op", "detail": "..."} to its standard output.
+These documents are deployed after all others, and are neither pruned nor rolled back.`,
 		Run: func(cmd *cobra.Command, args []string) {
 			var err error
 			opt.Namespace, err = cmd.Flags().GetString("namespace")
 			util.Check(err)
 
 			// Check file
-			if opt.InputFile == "" {
-				util.Check(errors.New("provided empty value for input file via the -f flag"))
+			if opt.Input.IsEmpty() {
+				util.Check(errors.New("provided no input file via the -f flag nor overlay via the -k flag"))
 			}
 
 			// Execute command
-			err = deploy.Execute(opt)
+			err = deploy.Execute(cmd.Context(), opt)
 			util.Check(err)
 
-			util.PrintSuccess("Successfully deployed resources")
+			if !opt.DryRun {
+				util.PrintSuccess("Successfully deployed resources")
+			}
 		},
 	}
 
 	// Register flags
-	cmd.Flags().StringVarP(&opt.InputFile, "file", "f", "", pkg.flagDescYaml)
+	addInputFlags(cmd, &opt.Input)
+	cmd.Flags().BoolVar(&opt.DryRun, "dry-run", false, "Print the changes that would be applied without deploying anything")
+	cmd.Flags().BoolVar(&opt.Prune, "prune", false, "Delete resources of the Namespace which are not declared in the YAML file")
+	cmd.Flags().BoolVarP(&opt.Yes, "yes", "y", false, "Delete resources with --prune without asking for confirmation")
+	cmd.Flags().BoolVar(&opt.Atomic, "atomic", false, "Roll back the changes if the deployment fails")
+	cmd.Flags().BoolVar(&opt.ContinueOnError, "continue-on-error", false, "Keep deploying the resources which do not depend on a failed one")
+	cmd.Flags().StringVar(&opt.FailedManifest, "failed-manifest", "", "YAML file to write the documents which failed or were skipped to")
+	cmd.Flags().IntVar(&opt.Parallelism, "parallelism", 0, "Maximum number of resources deployed at once, 0 is unlimited")
+	cmd.Flags().StringToIntVar(&opt.KindParallelism, "parallelism-per-kind", map[string]int{}, "Maximum number of resources of a kind deployed at once, e.g. Agent=5")
+	cmd.Flags().IntVar(&opt.Retries, "retries", 0, "Number of times a resource is deployed again after a transient failure")
+	cmd.Flags().StringToIntVar(&opt.KindRetries, "retries-per-kind", map[string]int{}, "Number of retries of a kind, e.g. Microservice=3")
+	cmd.Flags().DurationVar(&opt.RetryBackoff, "retry-backoff", time.Second, "Delay before the first retry, doubled after each retry")
+	cmd.Flags().BoolVar(&opt.Wait, "wait", false, "Wait until the deployed microservices are RUNNING")
+	cmd.Flags().DurationVar(&opt.WaitTimeout, "wait-timeout", wait.DefaultTimeout, "Maximum duration of --wait, e.g. 10m. 0 waits until the command is cancelled")
 
 	return cmd
 }
@@ -42,7 +42,7 @@ func newDescribeControllerCommand() *cobra.Command {
 			util.Check(err)
 
 			// Execute the command
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 		},
 	}
@@ -41,7 +41,7 @@ func newDescribeControlPlaneCommand() *cobra.Command {
 			util.Check(err)
 
 			// Execute the command
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 		},
 	}
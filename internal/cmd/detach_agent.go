@@ -42,7 +42,7 @@ The Agent stack will not be uninstalled from the host.`,
 
 			// Run the command
 			exe := detach.NewExecutor(namespace, name, force)
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			util.PrintSuccess("Successfully detached " + name)
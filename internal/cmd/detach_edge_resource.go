@@ -38,7 +38,7 @@ func newDetachEdgeResourceCommand() *cobra.Command {
 
 			// Run the command
 			exe := detach.NewExecutor(namespace, name, version, agent)
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			msg := fmt.Sprintf("Successfully detached %s/%s", name, version)
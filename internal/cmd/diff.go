@@ -0,0 +1,60 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/diff"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newDiffCommand() *cobra.Command {
+	// Instantiate options
+	opt := &diff.Options{}
+
+	// Instantiate command
+	cmd := &cobra.Command{
+		Use: "diff",
+		Example: `diff -f ecn.yaml
+        application.yaml
+        microservice.yaml`,
+		Args:  cobra.ExactArgs(0),
+		Short: "Show the differences between YAML documents and the live state of their resources",
+		Long: `Show a unified diff between the YAML documents accepted by deploy and the live state of their resources.
+
+The live state is generated the same way as the describe command. Fields which are not set in the YAML documents are ignored.
+Resources which are not deployed yet are shown as entirely added. CatalogItem documents are skipped.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			opt.Namespace, err = cmd.Flags().GetString("namespace")
+			util.Check(err)
+
+			// Check file
+			if opt.Input.IsEmpty() {
+				util.Check(errors.New("provided no input file via the -f flag nor overlay via the -k flag"))
+			}
+
+			// Execute command
+			err = diff.Execute(cmd.Context(), opt)
+			util.Check(err)
+		},
+	}
+
+	// Register flags
+	addInputFlags(cmd, &opt.Input)
+
+	return cmd
+}
@@ -0,0 +1,60 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/schema"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newGenerateSchemaCommand() *cobra.Command {
+	// Instantiate options
+	opt := &schema.Options{}
+
+	cmd := &cobra.Command{
+		Use:   "schema [KIND]",
+		Short: "Generate the JSON Schema of the YAML documents",
+		Long: `Generate the JSON Schema of the YAML documents accepted by deploy, derived from the same types as the validate command.
+
+Without a KIND, the schema covers the documents of every kind and checks their spec against the schema of their kind.
+With --output-dir, the schema of all kinds is written to ` + schema.Filename + ` along with the schema of each kind to KIND.json.
+
+To get autocompletion in editors using the YAML language server, start your files with the modeline
+'# yaml-language-server: $schema=<path to ` + schema.Filename + `>' or map the schema to your files in the yaml.schemas setting.`,
+		Example: `iofogctl generate schema > iofogctl.json
+iofogctl generate schema Agent
+iofogctl generate schema -o schemas/`,
+		Args: cobra.MaximumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if len(args) == 1 {
+				opt.Kind = args[0]
+			}
+
+			// Execute the command
+			err := schema.Execute(opt)
+			util.Check(err)
+
+			if opt.OutputDir != "" {
+				util.PrintSuccess(fmt.Sprintf("JSON Schemas generated at %s", opt.OutputDir))
+			}
+		},
+	}
+
+	cmd.Flags().StringVarP(&opt.OutputDir, "output-dir", "o", "", "Directory to write a file per schema to")
+
+	return cmd
+}
@@ -80,7 +80,7 @@ Resources like Agents will require a working Controller in the namespace to disp
 			util.Check(err)
 
 			// Execute the get command
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 		},
 	}
@@ -0,0 +1,45 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"github.com/eclipse-iofog/iofogctl/v3/internal/history"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newHistoryApplicationCommand() *cobra.Command {
+	opt := &history.Options{}
+	cmd := &cobra.Command{
+		Use:   "application NAME",
+		Short: "List the revisions of an application",
+		Long: `List the revisions of an application.
+
+A revision is recorded each time the application is deployed with a different spec, along with its timestamp and hash.
+The latest 10 revisions are kept. Use the rollback command to deploy the spec of a revision again.`,
+		Example: `iofogctl history application NAME`,
+		Args:    cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			opt.Name = args[0]
+			opt.Namespace, err = cmd.Flags().GetString("namespace")
+			util.Check(err)
+
+			// Execute the command
+			err = history.Execute(opt)
+			util.Check(err)
+		},
+	}
+	return cmd
+}
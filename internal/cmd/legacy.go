@@ -72,10 +72,9 @@ func localExecute(container string, localCLI, localCmd []string) {
 	}
 }
 
-func remoteExec(user, host, keyFile string, port int, cliCmd string, cmd []string) {
-	ssh, err := util.NewSecureShellClient(user, host, keyFile)
+func remoteExec(sshConfig rsc.SSH, host, cliCmd string, cmd []string) {
+	ssh, err := sshConfig.NewClient(host)
 	util.Check(err)
-	ssh.SetPort(port)
 	util.Check(ssh.Connect())
 	defer util.Log(ssh.Disconnect)
 
@@ -134,7 +133,7 @@ iofogctl legacy agent      NAME COMMAND`,
 					if controller.ValidateSSH() != nil {
 						util.Check(fmt.Errorf(sshErrMsg, "Controller", controller.Name))
 					}
-					remoteExec(controller.SSH.User, controller.Host, controller.SSH.KeyFile, controller.SSH.Port, "sudo iofog-controller", args[2:])
+					remoteExec(controller.SSH, controller.Host, "sudo iofog-controller", args[2:])
 				case *rsc.LocalController:
 					localExecute(install.GetLocalContainerName("controller", false), cliCommand, args[2:])
 				}
@@ -159,7 +158,7 @@ iofogctl legacy agent      NAME COMMAND`,
 					if agent.ValidateSSH() != nil {
 						util.Check(fmt.Errorf(sshErrMsg, "Agent", agent.Name))
 					}
-					remoteExec(agent.SSH.User, agent.Host, agent.SSH.KeyFile, agent.SSH.Port, "sudo iofog-agent", args[2:])
+					remoteExec(agent.SSH, agent.Host, "sudo iofog-agent", args[2:])
 				}
 			default:
 				util.Check(util.NewInputError("Unknown legacy CLI " + resource))
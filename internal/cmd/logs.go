@@ -40,7 +40,7 @@ func newLogsCommand() *cobra.Command {
 			util.Check(err)
 
 			// Run the logs command
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 		},
 	}
@@ -38,7 +38,7 @@ func newMoveAgentCommand() *cobra.Command {
 
 			// Detach
 			exe := detach.NewExecutor(namespace, name, force)
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 			// Invalidate cache between Executor invocations
 			if namespace == destNamespace {
@@ -46,7 +46,7 @@ func newMoveAgentCommand() *cobra.Command {
 			}
 			// Attach
 			exe = attach.NewExecutor(&attach.Options{Name: name, Namespace: destNamespace})
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			util.PrintSuccess(getMoveSuccessMessage("Agent", name, "Namespace", destNamespace))
@@ -16,18 +16,31 @@ package cmd
 import (
 	"fmt"
 	"strings"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	"github.com/spf13/cobra"
 )
 
 var pkg struct {
-	flagDescDetached string
-	flagDescYaml     string
-	succRename       string
-	succMove         string
+	flagDescDetached  string
+	flagDescYaml      string
+	flagDescRecursive string
+	flagDescOverlay   string
+	flagDescValues    string
+	flagDescSet       string
+	flagDescEnv       string
+	succRename        string
+	succMove          string
 }
 
 func init() {
 	pkg.flagDescDetached = "Specify command is to run against detached resources"
-	pkg.flagDescYaml = "YAML file containing specifications for ioFog resources to deploy"
+	pkg.flagDescYaml = "YAML file, directory or glob containing specifications for ioFog resources, or - for stdin. Can be repeated"
+	pkg.flagDescOverlay = "Directory containing an overlay.yaml file, which patches the documents of its resources"
+	pkg.flagDescRecursive = "Read the YAML files of the subdirectories of the directories provided via the -f flag"
+	pkg.flagDescValues = "YAML file of values to substitute to the ${key} references of the input files. Can be repeated"
+	pkg.flagDescSet = "Value to substitute to the ${key} references of the input files, e.g. image.tag=1.0. Can be repeated"
+	pkg.flagDescEnv = "Substitute environment variables to the ${key} references of the input files without value"
 	pkg.succRename = "Successfully renamed %s %s to %s"
 	pkg.succMove = "Successfully moved %s %s to %s %s"
 }
@@ -39,3 +52,12 @@ func getRenameSuccessMessage(resource, oldName, newName string) string {
 func getMoveSuccessMessage(resource, name, otherResource, otherName string) string {
 	return fmt.Sprintf(pkg.succRename, resource, name, otherResource, otherName)
 }
+
+func addInputFlags(cmd *cobra.Command, opt *execute.InputOptions) {
+	cmd.Flags().StringArrayVarP(&opt.Files, "file", "f", []string{}, pkg.flagDescYaml)
+	cmd.Flags().StringVarP(&opt.Overlay, "overlay", "k", "", pkg.flagDescOverlay)
+	cmd.Flags().BoolVarP(&opt.Recursive, "recursive", "R", false, pkg.flagDescRecursive)
+	cmd.Flags().StringArrayVar(&opt.ValuesFiles, "values", []string{}, pkg.flagDescValues)
+	cmd.Flags().StringArrayVar(&opt.Set, "set", []string{}, pkg.flagDescSet)
+	cmd.Flags().BoolVar(&opt.Env, "env", false, pkg.flagDescEnv)
+}
@@ -0,0 +1,60 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os/exec"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/plugin"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+// addPluginCommands adds a command for each plugin of PATH whose name is not taken by a command of iofogctl
+func addPluginCommands(root *cobra.Command) {
+	taken := map[string]bool{"help": true, "completion": true}
+	for _, cmd := range root.Commands() {
+		taken[cmd.Name()] = true
+		for _, alias := range cmd.Aliases {
+			taken[alias] = true
+		}
+	}
+	for _, plg := range plugin.List() {
+		if taken[plg.Name] {
+			continue
+		}
+		root.AddCommand(newPluginCommand(plg))
+	}
+}
+
+func newPluginCommand(plg plugin.Plugin) *cobra.Command {
+	return &cobra.Command{
+		Use:   plg.Name,
+		Short: fmt.Sprintf("Run the %s plugin", plg.Path),
+		Long: fmt.Sprintf(`Run the %s plugin.
+All arguments and flags are passed to the plugin.`, plg.Path),
+		DisableFlagParsing: true,
+		Run: func(cmd *cobra.Command, args []string) {
+			err := plugin.Run(cmd.Context(), plg, args)
+			// The plugin reports its own errors
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 {
+				util.Exit(exitErr.ExitCode())
+			}
+			util.Check(err)
+		},
+	}
+}
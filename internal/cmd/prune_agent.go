@@ -36,7 +36,7 @@ func newPruneAgentCommand() *cobra.Command {
 
 			// Run the command
 			exe := prune.NewExecutor(namespace, name, useDetached)
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			util.PrintSuccess("Successfully pruned " + name)
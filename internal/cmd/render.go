@@ -0,0 +1,64 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/render"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newRenderCommand() *cobra.Command {
+	// Instantiate options
+	opt := &render.Options{}
+
+	// Instantiate command
+	cmd := &cobra.Command{
+		Use: "render",
+		Example: `render -f application.yaml --values prod.yaml
+
+render -f apps/ --set image.tag=1.2.0 --set agent=agent-1
+
+render -f application.yaml --env
+
+render -k overlays/prod`,
+		Args:  cobra.ExactArgs(0),
+		Short: "Print YAML files rendered with values",
+		Long: `Print the YAML files accepted by deploy, delete, diff and connect rendered with values.
+
+The ${key} references of the files are substituted with the values of --set, then of the --values files, then of the environment variables if --env is set.
+The files are left unchanged if neither --values, --set nor --env is provided.
+Later --values files override earlier ones. The keys of nested maps are joined with dots, e.g. ${image.tag}.
+Use ${key:-default} to provide a default value, and $${ to write a literal ${.
+
+The documents of the overlay provided via -k are printed with its patches applied, see the deploy command.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			// Check file
+			if opt.Input.IsEmpty() {
+				util.Check(errors.New("provided no input file via the -f flag nor overlay via the -k flag"))
+			}
+
+			// Execute command
+			err := render.Execute(opt)
+			util.Check(err)
+		},
+	}
+
+	// Register flags
+	addInputFlags(cmd, &opt.Input)
+
+	return cmd
+}
@@ -27,11 +27,18 @@ func newRollbackCommand() *cobra.Command {
 	var opt rollback.Options
 
 	cmd := &cobra.Command{
-		Use:     "rollback RESOURCE NAME",
-		Short:   "Rollback ioFog resources",
-		Long:    `Rollback ioFog resources to latest versions available.`,
-		Example: `iofogctl rollback agent NAME`,
-		Args:    cobra.ExactArgs(2),
+		Use:   "rollback RESOURCE NAME",
+		Short: "Rollback ioFog resources",
+		Long: `Rollback ioFog resources to latest versions available.
+
+Agents are rolled back to their previous version.
+
+Applications are deployed again with the spec of a revision, the previous one unless --to-revision is provided.
+A revision of an Application is recorded each time it is deployed with a different spec, see the history command.`,
+		Example: `iofogctl rollback agent NAME
+iofogctl rollback application NAME
+iofogctl rollback application NAME --to-revision 3`,
+		Args: cobra.ExactArgs(2),
 		Run: func(cmd *cobra.Command, args []string) {
 			// Get resource type and name
 			opt.ResourceType = args[0]
@@ -47,12 +54,18 @@ func newRollbackCommand() *cobra.Command {
 			util.Check(err)
 
 			// Execute the command
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
+			if opt.ResourceType == "application" {
+				util.PrintSuccess(fmt.Sprintf("Successfully rolled back Application %s", opt.Name))
+				return
+			}
 			util.PrintSuccess(fmt.Sprintf("Succesfully scheduled rollback for %s %s", strings.Title(opt.ResourceType), opt.Name))
 		},
 	}
 
+	cmd.Flags().IntVar(&opt.Revision, "to-revision", 0, "Revision of the application to roll back to, the previous one by default")
+
 	return cmd
 }
@@ -14,6 +14,9 @@
 package cmd
 
 import (
+	"context"
+	"time"
+
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog/install"
@@ -45,6 +48,21 @@ func NewRootCommand() *cobra.Command {
 		PreRun: func(cmd *cobra.Command, args []string) {
 			printHeader()
 		},
+		PersistentPreRun: func(cmd *cobra.Command, args []string) {
+			ctx := cmd.Context()
+			if timeout > 0 {
+				var cancel context.CancelFunc
+				ctx, cancel = context.WithTimeout(ctx, timeout)
+				util.OnExit(cancel)
+				cmd.SetContext(ctx)
+			}
+			// Persist the changes made before the command was cancelled
+			util.OnExit(func() {
+				if ctx.Err() != nil {
+					util.Log(config.Flush)
+				}
+			})
+		},
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.SetArgs([]string{"-h"})
 			err := cmd.Execute()
@@ -61,6 +79,10 @@ func NewRootCommand() *cobra.Command {
 	cmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Toggle for displaying verbose output of iofogctl")
 	cmd.PersistentFlags().BoolVar(&debug, "debug", false, "Toggle for displaying verbose output of API clients (HTTP and SSH)")
 	cmd.PersistentFlags().StringP("namespace", "n", config.GetDefaultNamespaceName(), "Namespace to execute respective command within")
+	cmd.PersistentFlags().DurationVar(&timeout, "timeout", 0, "Maximum duration of the command, e.g. 10m. The command is cancelled once exceeded")
+	cmd.PersistentFlags().StringVar(&hostKeyChecking, "host-key-checking", string(util.StrictHostKeyChecking), "Verification of SSH host keys which are not pinned: strict rejects unknown hosts, accept-new trusts them on first use and adds them to --known-hosts, off accepts any key")
+	cmd.PersistentFlags().StringVar(&knownHosts, "known-hosts", "", "known_hosts file used to verify SSH host keys (default ~/.ssh/known_hosts)")
+	cmd.PersistentFlags().StringVar(&auditLog, "audit-log", "", "File to append a JSON line to for each command run on remote hosts and containers and each Controller API call, with secrets redacted")
 
 	// Register all commands
 	cmd.AddCommand(
@@ -74,6 +96,10 @@ func NewRootCommand() *cobra.Command {
 		newCreateCommand(),
 		newGetCommand(),
 		newDescribeCommand(),
+		newDiffCommand(),
+		newRenderCommand(),
+		newValidateCommand(),
+		newGenerateCommand(),
 		newLogsCommand(),
 		newLegacyCommand(),
 		newVersionCommand(),
@@ -87,7 +113,9 @@ func NewRootCommand() *cobra.Command {
 		newDockerPruneCommand(),
 		newUpgradeCommand(),
 		newRollbackCommand(),
+		newHistoryCommand(),
 	)
+	addPluginCommands(cmd)
 
 	return cmd
 }
@@ -98,6 +126,18 @@ var verbose bool
 // Toggle set by --debug persistent flag
 var debug bool
 
+// Duration set by --timeout persistent flag
+var timeout time.Duration
+
+// Mode set by --host-key-checking persistent flag
+var hostKeyChecking string
+
+// File set by --known-hosts persistent flag
+var knownHosts string
+
+// File set by --audit-log persistent flag
+var auditLog string
+
 // Callback for cobra on initialization
 func initialize() {
 	client.SetGlobalRetries(client.Retries{
@@ -113,4 +153,6 @@ func initialize() {
 	install.SetVerbosity(verbose)
 	util.SpinEnable(!verbose && !debug)
 	util.SetDebug(debug)
+	util.Check(util.SetHostKeyChecking(util.HostKeyChecking(hostKeyChecking), knownHosts))
+	util.Check(util.SetAuditLog(auditLog))
 }
@@ -15,6 +15,7 @@ package cmd
 
 import (
 	startapplication "github.com/eclipse-iofog/iofogctl/v3/internal/start"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/wait"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 	"github.com/spf13/cobra"
 )
@@ -22,11 +23,15 @@ import (
 func newStartApplicationCommand() *cobra.Command {
 	opt := startapplication.Options{}
 	cmd := &cobra.Command{
-		Use:     "application NAME",
-		Short:   "Starts an application",
-		Long:    "Starts an application",
-		Example: `iofogctl start application NAME`,
-		Args:    cobra.ExactValidArgs(1),
+		Use:   "application NAME",
+		Short: "Starts an application",
+		Long: `Starts an application.
+
+Use --wait to wait until the microservices of the application are RUNNING.
+The command fails with the status of each microservice if any of them is FAILED, or if --wait-timeout or --timeout expires first.`,
+		Example: `iofogctl start application NAME
+iofogctl start application NAME --wait --wait-timeout 10m`,
+		Args: cobra.ExactValidArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			var err error
 			if len(args) == 0 {
@@ -39,11 +44,13 @@ func newStartApplicationCommand() *cobra.Command {
 			exe := startapplication.NewExecutor(opt)
 
 			// Execute the command
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			util.PrintSuccess("Successfully started Application " + opt.Name)
 		},
 	}
+	cmd.Flags().BoolVar(&opt.Wait, "wait", false, "Wait until the microservices of the application are RUNNING")
+	cmd.Flags().DurationVar(&opt.WaitTimeout, "wait-timeout", wait.DefaultTimeout, "Maximum duration of --wait, e.g. 10m. 0 waits until the command is cancelled")
 	return cmd
 }
@@ -39,7 +39,7 @@ func newStopApplicationCommand() *cobra.Command {
 			exe := stopapplication.NewExecutor(opt)
 
 			// Execute the command
-			err = exe.Execute()
+			err = exe.Execute(cmd.Context())
 			util.Check(err)
 
 			util.PrintSuccess("Successfully stopped Application " + opt.Name)
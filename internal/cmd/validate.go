@@ -0,0 +1,65 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package cmd
+
+import (
+	"errors"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/validate"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"github.com/spf13/cobra"
+)
+
+func newValidateCommand() *cobra.Command {
+	// Instantiate options
+	opt := &validate.Options{}
+
+	// Instantiate command
+	cmd := &cobra.Command{
+		Use: "validate",
+		Example: `validate -f ecn.yaml
+
+validate -f apps/ -R --values prod.yaml`,
+		Args:  cobra.ExactArgs(0),
+		Short: "Validate YAML documents without deploying them",
+		Long: `Validate the YAML documents accepted by deploy without contacting the Control Plane.
+
+Each document is checked against the specification of its kind, except the kinds handled by plugins, see the deploy command. Then the documents are checked against each other:
+names must be unique per kind and Namespace, Microservices and Volumes must refer to Agents which are declared or already deployed in their Namespace,
+and Routes must refer to Microservices of their Application when it is declared.
+
+All problems are reported at once, each prefixed by its file, line and column.`,
+		Run: func(cmd *cobra.Command, args []string) {
+			var err error
+			opt.Namespace, err = cmd.Flags().GetString("namespace")
+			util.Check(err)
+
+			// Check file
+			if opt.Input.IsEmpty() {
+				util.Check(errors.New("provided no input file via the -f flag nor overlay via the -k flag"))
+			}
+
+			// Execute command
+			err = validate.Execute(opt)
+			util.Check(err)
+
+			util.PrintSuccess("The YAML documents are valid")
+		},
+	}
+
+	// Register flags
+	addInputFlags(cmd, &opt.Input)
+
+	return cmd
+}
@@ -137,7 +137,8 @@ func DeleteNamespace(name string) error {
 
 	delete(namespaces, name)
 
-	return nil
+	// Remove the revisions of the resources of the namespace
+	return os.RemoveAll(getRevisionsDirectory(name))
 }
 
 // RenameNamespace renames a namespace
@@ -151,6 +152,9 @@ func RenameNamespace(name, newName string) error {
 	if err := os.Rename(getNamespaceFile(name), getNamespaceFile(newName)); err != nil {
 		return err
 	}
+	if err := os.Rename(getRevisionsDirectory(name), getRevisionsDirectory(newName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
 	if name == conf.DefaultNamespace {
 		return SetDefaultNamespace(newName)
 	}
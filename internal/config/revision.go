@@ -0,0 +1,133 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package config
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	revisionsDirname = "revisions/"
+	// revisionsLimit is the number of revisions kept for each resource
+	revisionsLimit = 10
+)
+
+// Revision is the spec of a resource as it was deployed
+type Revision struct {
+	Number    int    `yaml:"revision"`
+	Timestamp string `yaml:"timestamp"`
+	Hash      string `yaml:"hash"`
+	Spec      string `yaml:"spec"`
+}
+
+type revisionsFile struct {
+	Revisions []Revision `yaml:"revisions"`
+}
+
+// getRevisionsDirectory returns the path of the directory of the revisions of the resources of a namespace
+func getRevisionsDirectory(namespace string) string {
+	return path.Join(configFolder, revisionsDirname, namespace)
+}
+
+// getRevisionsFile returns the path of the file of the revisions of a resource
+func getRevisionsFile(namespace string, kind Kind, name string) string {
+	return path.Join(getRevisionsDirectory(namespace), string(kind), name+".yaml")
+}
+
+// GetRevisions returns the revisions of a resource, oldest first
+func GetRevisions(namespace string, kind Kind, name string) ([]Revision, error) {
+	file := revisionsFile{}
+	if err := util.UnmarshalYAML(getRevisionsFile(namespace, kind, name), &file); err != nil {
+		if os.IsNotExist(err) {
+			return []Revision{}, nil
+		}
+		return nil, err
+	}
+	return file.Revisions, nil
+}
+
+// GetRevision returns a revision of a resource
+func GetRevision(namespace string, kind Kind, name string, number int) (Revision, error) {
+	revisions, err := GetRevisions(namespace, kind, name)
+	if err != nil {
+		return Revision{}, err
+	}
+	for _, revision := range revisions {
+		if revision.Number == number {
+			return revision, nil
+		}
+	}
+	return Revision{}, util.NewNotFoundError(fmt.Sprintf("Could not find revision %d of %s %s", number, kind, name))
+}
+
+// AddRevision records the spec of a resource as its latest revision, unless the spec is the same as the latest revision.
+// Only the latest revisions are kept
+func AddRevision(namespace string, kind Kind, name string, spec []byte) error {
+	revisions, err := GetRevisions(namespace, kind, name)
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(spec)
+	revision := Revision{
+		Number:    1,
+		Timestamp: util.NowRFC(),
+		Hash:      hex.EncodeToString(sum[:]),
+		Spec:      string(spec),
+	}
+	if len(revisions) > 0 {
+		latest := revisions[len(revisions)-1]
+		if latest.Hash == revision.Hash {
+			return nil
+		}
+		revision.Number = latest.Number + 1
+	}
+	revisions = append(revisions, revision)
+	if len(revisions) > revisionsLimit {
+		revisions = revisions[len(revisions)-revisionsLimit:]
+	}
+
+	marshal, err := yaml.Marshal(revisionsFile{Revisions: revisions})
+	if err != nil {
+		return err
+	}
+	filename := getRevisionsFile(namespace, kind, name)
+	if err := os.MkdirAll(path.Dir(filename), 0755); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(filename, marshal, 0644)
+}
+
+// DeleteRevisions removes the revisions of a resource
+func DeleteRevisions(namespace string, kind Kind, name string) error {
+	if err := os.Remove(getRevisionsFile(namespace, kind, name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
+
+// RenameRevisions moves the revisions of a resource to its new name
+func RenameRevisions(namespace string, kind Kind, name, newName string) error {
+	if err := os.Rename(getRevisionsFile(namespace, kind, name), getRevisionsFile(namespace, kind, newName)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package config
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestRevisions(t *testing.T) {
+	configFolder = t.TempDir()
+
+	for idx := 1; idx <= revisionsLimit+2; idx++ {
+		spec := []byte(fmt.Sprintf("name: app\nversion: %d\n", idx))
+		if err := AddRevision("default", ApplicationKind, "app", spec); err != nil {
+			t.Fatal(err)
+		}
+		// The same spec is not recorded twice
+		if err := AddRevision("default", ApplicationKind, "app", spec); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	revisions, err := GetRevisions("default", ApplicationKind, "app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(revisions) != revisionsLimit {
+		t.Fatalf("Expected %d revisions, found %d", revisionsLimit, len(revisions))
+	}
+	if revisions[0].Number != 3 || revisions[len(revisions)-1].Number != revisionsLimit+2 {
+		t.Errorf("Expected revisions 3 to %d, found %d to %d", revisionsLimit+2, revisions[0].Number, revisions[len(revisions)-1].Number)
+	}
+
+	revision, err := GetRevision("default", ApplicationKind, "app", 5)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if revision.Spec != "name: app\nversion: 5\n" {
+		t.Errorf("Unexpected spec of revision 5: %s", revision.Spec)
+	}
+	if _, err := GetRevision("default", ApplicationKind, "app", 1); err == nil {
+		t.Error("Expected revision 1 to be removed")
+	}
+
+	if err := RenameRevisions("default", ApplicationKind, "app", "renamed"); err != nil {
+		t.Fatal(err)
+	}
+	if revisions, _ := GetRevisions("default", ApplicationKind, "renamed"); len(revisions) != revisionsLimit {
+		t.Errorf("Expected the revisions to be renamed")
+	}
+	if err := DeleteRevisions("default", ApplicationKind, "renamed"); err != nil {
+		t.Fatal(err)
+	}
+	if revisions, _ := GetRevisions("default", ApplicationKind, "renamed"); len(revisions) != 0 {
+		t.Errorf("Expected the revisions to be deleted")
+	}
+}
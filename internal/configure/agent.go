@@ -14,6 +14,8 @@
 package configure
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
@@ -44,7 +46,7 @@ func (exe *agentExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *agentExecutor) Execute() error {
+func (exe *agentExecutor) Execute(ctx context.Context) error {
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
 		return err
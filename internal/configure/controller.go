@@ -14,6 +14,8 @@
 package configure
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
@@ -48,7 +50,7 @@ func (exe *controllerExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *controllerExecutor) Execute() error {
+func (exe *controllerExecutor) Execute(ctx context.Context) error {
 	// Get config
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
@@ -103,11 +105,6 @@ func (exe *controllerExecutor) remoteConfigure(controlPlane *rsc.RemoteControlPl
 		controller.SSH.Port = exe.remoteConfig.port
 	}
 
-	// Add port if not specified or existing
-	if controller.Host != "" && controller.SSH.Port == 0 {
-		controller.SSH.Port = 22
-	}
-
 	if err := controlPlane.UpdateController(controller); err != nil {
 		return err
 	}
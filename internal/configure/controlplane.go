@@ -14,6 +14,8 @@
 package configure
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
@@ -49,7 +51,7 @@ func (exe *controlPlaneExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *controlPlaneExecutor) Execute() error {
+func (exe *controlPlaneExecutor) Execute(ctx context.Context) error {
 	// Get config
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
@@ -14,6 +14,8 @@
 package configure
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
@@ -32,7 +34,7 @@ func (exe *defaultNamespaceExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *defaultNamespaceExecutor) Execute() error {
+func (exe *defaultNamespaceExecutor) Execute(ctx context.Context) error {
 	if exe.name == "" {
 		return util.NewInputError("Must specify Namespace")
 	}
@@ -14,6 +14,8 @@
 package configure
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
@@ -29,7 +31,7 @@ func newMultipleExecutor(opt *Options) *multipleExecutor {
 	}
 }
 
-func (exe *multipleExecutor) Execute() (err error) {
+func (exe *multipleExecutor) Execute(ctx context.Context) (err error) {
 	// Instantiate executor list
 	var executors []execute.Executor
 
@@ -49,7 +51,7 @@ func (exe *multipleExecutor) Execute() (err error) {
 
 	// Execute
 	for _, executor := range executors {
-		if err := executor.Execute(); err != nil {
+		if err := executor.Execute(ctx); err != nil {
 			return err
 		}
 	}
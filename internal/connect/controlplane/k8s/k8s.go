@@ -14,6 +14,8 @@
 package connectk8scontrolplane
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	connectcontrolplane "github.com/eclipse-iofog/iofogctl/v3/internal/connect/controlplane"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
@@ -65,12 +67,13 @@ func NewExecutor(namespace, name string, yaml []byte, kind config.Kind) (execute
 	return newKubernetesExecutor(&controlPlane, namespace), nil
 }
 
-func (exe *kubernetesExecutor) Execute() (err error) {
+func (exe *kubernetesExecutor) Execute(ctx context.Context) (err error) {
 	// Instantiate Kubernetes cluster object
 	k8s, err := install.NewKubernetes(exe.controlPlane.KubeConfig, exe.namespace)
 	if err != nil {
 		return
 	}
+	k8s.SetContext(ctx)
 
 	// Check the resources exist in K8s namespace
 	if err = k8s.ExistsInNamespace(exe.namespace); err != nil {
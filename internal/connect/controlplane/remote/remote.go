@@ -14,6 +14,7 @@
 package connectremotecontrolplane
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -102,7 +103,7 @@ func (exe *remoteExecutor) GetName() string {
 	return "Remote Control Plane"
 }
 
-func (exe *remoteExecutor) Execute() (err error) {
+func (exe *remoteExecutor) Execute(ctx context.Context) (err error) {
 	// Establish connection
 	controllers := exe.controlPlane.GetControllers()
 	if len(controllers) == 0 {
@@ -14,6 +14,7 @@
 package connect
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 
@@ -28,7 +29,7 @@ import (
 type Options struct {
 	Namespace          string
 	OverwriteNamespace bool
-	InputFile          string
+	Input              execute.InputOptions
 	ControllerName     string
 	ControllerEndpoint string
 	KubeConfig         string
@@ -52,7 +53,7 @@ var kindHandlers = map[config.Kind]func(*execute.KindHandlerOpt) (execute.Execut
 	},
 }
 
-func Execute(opt *Options) error {
+func Execute(ctx context.Context, opt *Options) error {
 	if opt.Generate {
 		return generateConnectionString(opt.Namespace)
 	}
@@ -62,7 +63,7 @@ func Execute(opt *Options) error {
 	}
 
 	// Check inputs
-	if opt.InputFile != "" && (opt.ControllerEndpoint != "" || opt.KubeConfig != "") {
+	if !opt.Input.IsEmpty() && (opt.ControllerEndpoint != "" || opt.KubeConfig != "") {
 		return util.NewInputError("Either use a YAML file or provide Controller endpoint or Kube config to connect")
 	}
 
@@ -90,13 +91,13 @@ func Execute(opt *Options) error {
 	// Flush at the end
 	defer config.Flush()
 
-	if opt.InputFile != "" {
-		return executeWithYAML(opt.InputFile, opt.Namespace)
+	if !opt.Input.IsEmpty() {
+		return executeWithYAML(ctx, &opt.Input, opt.Namespace)
 	}
-	return manualExecute(opt)
+	return manualExecute(ctx, opt)
 }
 
-func manualExecute(opt *Options) (err error) {
+func manualExecute(ctx context.Context, opt *Options) (err error) {
 	if err := hasAllFlags(opt); err != nil {
 		return err
 	}
@@ -116,20 +117,20 @@ func manualExecute(opt *Options) (err error) {
 	}
 
 	// Execute
-	if err := exe.Execute(); err != nil {
+	if err := exe.Execute(ctx); err != nil {
 		return err
 	}
 	return nil
 }
 
-func executeWithYAML(yamlFile, namespace string) error {
-	executorsMap, err := execute.GetExecutorsFromYAML(yamlFile, namespace, kindHandlers)
+func executeWithYAML(ctx context.Context, inputOpt *execute.InputOptions, namespace string) error {
+	executorsMap, err := execute.GetExecutorsFromYAML(inputOpt, namespace, kindHandlers)
 	if err != nil {
 		return err
 	}
 
 	for idx := range kindOrder {
-		if errs := execute.RunExecutors(executorsMap[kindOrder[idx]], fmt.Sprintf("connect %s", kindOrder[idx])); len(errs) > 0 {
+		if errs := execute.RunExecutors(ctx, executorsMap[kindOrder[idx]], fmt.Sprintf("connect %s", kindOrder[idx])); len(errs) > 0 {
 			return execute.CoalesceErrors(errs)
 		}
 	}
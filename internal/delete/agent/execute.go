@@ -14,6 +14,7 @@
 package deleteagent
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
@@ -38,7 +39,7 @@ func (exe executor) GetName() string {
 	return exe.name
 }
 
-func (exe executor) Execute() (err error) {
+func (exe executor) Execute(ctx context.Context) (err error) {
 	util.SpinStart("Deleting Agent")
 
 	ns, err := config.GetNamespace(exe.namespace)
@@ -82,11 +83,11 @@ func (exe executor) Execute() (err error) {
 	// Remove from Controller
 	switch agent := baseAgent.(type) {
 	case *rsc.LocalAgent:
-		if err = exe.deleteLocalContainer(); err != nil {
+		if err = exe.deleteLocalContainer(ctx); err != nil {
 			util.PrintInfo(fmt.Sprintf("Could not remove Agent container %s. Error: %s\n", agent.GetHost(), err.Error()))
 		}
 	case *rsc.RemoteAgent:
-		if err = exe.deleteRemoteAgent(agent); err != nil {
+		if err = exe.deleteRemoteAgent(ctx, agent); err != nil {
 			util.PrintInfo(fmt.Sprintf("Could not remove Agent from the remote host %s. Error: %s\n", agent.GetHost(), err.Error()))
 		}
 	}
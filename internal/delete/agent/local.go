@@ -14,6 +14,7 @@
 package deleteagent
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -22,11 +23,12 @@ import (
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog/install"
 )
 
-func (exe executor) deleteLocalContainer() error {
+func (exe executor) deleteLocalContainer(ctx context.Context) error {
 	client, err := install.NewLocalContainerClient()
 	if err != nil {
 		return err
 	}
+	client.SetContext(ctx)
 
 	// Clean agent containers (normal and system)
 	if errClean := client.CleanContainer(install.GetLocalContainerName("agent", false)); errClean != nil {
@@ -14,6 +14,7 @@
 package deleteagent
 
 import (
+	"context"
 	"fmt"
 
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
@@ -21,7 +22,7 @@ import (
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
-func (exe executor) deleteRemoteAgent(agent *rsc.RemoteAgent) error {
+func (exe executor) deleteRemoteAgent(ctx context.Context, agent *rsc.RemoteAgent) error {
 	// Stop and remove the Agent process on remote server
 	if agent.ValidateSSH() != nil {
 		util.PrintNotify("Could not stop daemon for Agent " + agent.Name + ". SSH details missing from local cofiguration. Use configure command to add SSH details.")
@@ -29,12 +30,16 @@ func (exe executor) deleteRemoteAgent(agent *rsc.RemoteAgent) error {
 		sshAgent, err := install.NewRemoteAgent(agent.SSH.User,
 			agent.Host,
 			agent.SSH.Port,
-			agent.SSH.KeyFile,
+			agent.SSH.GetAuth(),
 			agent.Name,
 			agent.UUID)
 		if err != nil {
 			return err
 		}
+		sshAgent.SetContext(ctx)
+		sshAgent.SetHostKey(agent.SSH.HostKey)
+		sshAgent.SetJumpHosts(agent.SSH.GetJumpHosts())
+		sshAgent.SetPrivilege(agent.SSH.GetPrivilege())
 		if err := sshAgent.Uninstall(); err != nil {
 			util.PrintNotify(fmt.Sprintf("Failed to stop daemon on Agent %s. %s", agent.Name, err.Error()))
 		}
@@ -14,6 +14,8 @@
 package deleteall
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	deleteagent "github.com/eclipse-iofog/iofogctl/v3/internal/delete/agent"
 	deletecontrolplane "github.com/eclipse-iofog/iofogctl/v3/internal/delete/controlplane"
@@ -23,7 +25,7 @@ import (
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
-func Execute(namespace string, useDetached, force bool) error {
+func Execute(ctx context.Context, namespace string, useDetached, force bool) error {
 	// Make sure to update config despite failure
 	defer config.Flush()
 
@@ -44,7 +46,7 @@ func Execute(namespace string, useDetached, force bool) error {
 			}
 			executors = append(executors, exe)
 		}
-		if err := runExecutors(executors); err != nil {
+		if err := runExecutors(ctx, executors); err != nil {
 			return err
 		}
 	}
@@ -81,7 +83,7 @@ func Execute(namespace string, useDetached, force bool) error {
 			}
 			executors = append(executors, exe)
 		}
-		if err := runExecutors(executors); err != nil {
+		if err := runExecutors(ctx, executors); err != nil {
 			return err
 		}
 	}
@@ -93,7 +95,7 @@ func Execute(namespace string, useDetached, force bool) error {
 		if err != nil {
 			return err
 		}
-		if err := exe.Execute(); err != nil {
+		if err := exe.Execute(ctx); err != nil {
 			return err
 		}
 	}
@@ -101,8 +103,8 @@ func Execute(namespace string, useDetached, force bool) error {
 	return nil
 }
 
-func runExecutors(executors []execute.Executor) error {
-	if errs, _ := execute.ForParallel(executors); len(errs) > 0 {
+func runExecutors(ctx context.Context, executors []execute.Executor) error {
+	if errs, _ := execute.ForParallel(ctx, executors); len(errs) > 0 {
 		return execute.CoalesceErrors(errs)
 	}
 	return nil
@@ -14,15 +14,17 @@
 package deleteapplication
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 )
 
-func Execute(namespace, name string) error {
+func Execute(ctx context.Context, namespace, name string) error {
 	// Get executor
 	exe, _ := NewExecutor(namespace, name)
 
 	// Execute deletion
-	if err := exe.Execute(); err != nil {
+	if err := exe.Execute(ctx); err != nil {
 		return err
 	}
 
@@ -14,7 +14,10 @@
 package deleteapplication
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
@@ -50,7 +53,7 @@ func (exe *Executor) init() (err error) {
 }
 
 // Execute deletes application by deleting its associated flow
-func (exe *Executor) Execute() (err error) {
+func (exe *Executor) Execute(ctx context.Context) (err error) {
 	util.SpinStart("Deleting Application")
 	if err := exe.init(); err != nil {
 		return err
@@ -59,7 +62,10 @@ func (exe *Executor) Execute() (err error) {
 	err = exe.client.DeleteApplication(exe.name)
 	// If notfound error, try legacy
 	if _, ok := err.(*client.NotFoundError); ok {
-		return exe.deleteLegacy()
+		err = exe.deleteLegacy()
+	}
+	if err != nil {
+		return err
 	}
-	return err
+	return config.DeleteRevisions(exe.namespace, config.ApplicationKind, exe.name)
 }
@@ -14,6 +14,8 @@
 package deletecatalogitem
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
@@ -39,7 +41,7 @@ func (exe *Executor) GetName() string {
 }
 
 // Execute deletes application by deleting its associated flow
-func (exe *Executor) Execute() error {
+func (exe *Executor) Execute(ctx context.Context) error {
 	util.SpinStart("Deleting Catalog item")
 	// Init remote resources
 	clt, err := clientutil.NewControllerClient(exe.namespace)
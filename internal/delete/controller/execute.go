@@ -14,11 +14,13 @@
 package deletecontroller
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
-func Execute(namespace, name string) error {
+func Execute(ctx context.Context, namespace, name string) error {
 	util.SpinStart("Deleting Controller")
 
 	// Get executor
@@ -28,7 +30,7 @@ func Execute(namespace, name string) error {
 	}
 
 	// Execute deletion
-	if err := exe.Execute(); err != nil {
+	if err := exe.Execute(ctx); err != nil {
 		return err
 	}
 
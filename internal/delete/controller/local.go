@@ -14,6 +14,7 @@
 package deletecontroller
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
@@ -43,7 +44,7 @@ func (exe *LocalExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *LocalExecutor) Execute() error {
+func (exe *LocalExecutor) Execute(ctx context.Context) error {
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
 		return err
@@ -52,6 +53,7 @@ func (exe *LocalExecutor) Execute() error {
 	if err != nil {
 		return err
 	}
+	client.SetContext(ctx)
 	// Get container config
 	// Clean container
 	if errClean := client.CleanContainer(exe.localControllerConfig.ContainerName); errClean != nil {
@@ -14,6 +14,7 @@
 package deletecontroller
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
@@ -41,7 +42,7 @@ func (exe *RemoteExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *RemoteExecutor) Execute() error {
+func (exe *RemoteExecutor) Execute(ctx context.Context) error {
 	// Get controller from config
 	baseCtrl, err := exe.controlPlane.GetController(exe.name)
 	if err != nil {
@@ -58,27 +59,35 @@ func (exe *RemoteExecutor) Execute() error {
 	sshAgent, err := install.NewRemoteAgent(ctrl.SSH.User,
 		ctrl.Host,
 		ctrl.SSH.Port,
-		ctrl.SSH.KeyFile,
+		ctrl.SSH.GetAuth(),
 		iofog.VanillaRouterAgentName,
 		"")
 	if err != nil {
 		return err
 	}
+	sshAgent.SetContext(ctx)
+	sshAgent.SetHostKey(ctrl.SSH.HostKey)
+	sshAgent.SetJumpHosts(ctrl.SSH.GetJumpHosts())
+	sshAgent.SetPrivilege(ctrl.SSH.GetPrivilege())
 	if err = sshAgent.Uninstall(); err != nil {
 		util.PrintNotify(fmt.Sprintf("Failed to stop daemon on Agent %s. %s", iofog.VanillaRouterAgentName, err.Error()))
 	}
 
 	// Instantiate Controller uninstaller
 	controllerOptions := &install.ControllerOptions{
-		User:            ctrl.SSH.User,
-		Host:            ctrl.Host,
-		Port:            ctrl.SSH.Port,
-		PrivKeyFilename: ctrl.SSH.KeyFile,
+		User:      ctrl.SSH.User,
+		Host:      ctrl.Host,
+		Port:      ctrl.SSH.Port,
+		Auth:      ctrl.SSH.GetAuth(),
+		HostKey:   ctrl.SSH.HostKey,
+		JumpHosts: ctrl.SSH.GetJumpHosts(),
+		Privilege: ctrl.SSH.GetPrivilege(),
 	}
 	installer, err := install.NewController(controllerOptions)
 	if err != nil {
 		return err
 	}
+	installer.SetContext(ctx)
 
 	// Uninstall Controller
 	if err := installer.Uninstall(); err != nil {
@@ -14,6 +14,8 @@
 package deletek8scontrolplane
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
@@ -38,7 +40,7 @@ func (exe *Executor) GetName() string {
 }
 
 // Execute deletes application by deleting its associated flow
-func (exe *Executor) Execute() (err error) {
+func (exe *Executor) Execute(ctx context.Context) (err error) {
 	// Get Control Plane
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
@@ -59,6 +61,7 @@ func (exe *Executor) Execute() (err error) {
 	if err != nil {
 		return err
 	}
+	k8s.SetContext(ctx)
 
 	// Delete Controller on cluster
 	err = k8s.DeleteControlPlane()
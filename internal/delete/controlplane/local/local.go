@@ -14,6 +14,8 @@
 package deletelocalcontrolplane
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	deletecontroller "github.com/eclipse-iofog/iofogctl/v3/internal/delete/controller"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
@@ -38,7 +40,7 @@ func (exe *Executor) GetName() string {
 }
 
 // Execute deletes application by deleting its associated flow
-func (exe *Executor) Execute() (err error) {
+func (exe *Executor) Execute(ctx context.Context) (err error) {
 	// Get Control Plane
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
@@ -55,7 +57,7 @@ func (exe *Executor) Execute() (err error) {
 	}
 
 	executor := deletecontroller.NewLocalExecutor(controlPlane, exe.namespace, controlPlane.Controller.GetName())
-	if err := executor.Execute(); err != nil {
+	if err := executor.Execute(ctx); err != nil {
 		return err
 	}
 
@@ -14,6 +14,8 @@
 package deleteremotecontrolplane
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	deletecontroller "github.com/eclipse-iofog/iofogctl/v3/internal/delete/controller"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
@@ -38,7 +40,7 @@ func (exe *Executor) GetName() string {
 }
 
 // Execute deletes application by deleting its associated flow
-func (exe *Executor) Execute() (err error) {
+func (exe *Executor) Execute(ctx context.Context) (err error) {
 	// Get Control Plane
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
@@ -61,7 +63,7 @@ func (exe *Executor) Execute() (err error) {
 		executors[idx] = exe
 	}
 
-	if err := runExecutors(executors); err != nil {
+	if err := runExecutors(ctx, executors); err != nil {
 		return err
 	}
 
@@ -70,8 +72,8 @@ func (exe *Executor) Execute() (err error) {
 	return config.Flush()
 }
 
-func runExecutors(executors []execute.Executor) error {
-	if errs, _ := execute.ForParallel(executors); len(errs) > 0 {
+func runExecutors(ctx context.Context, executors []execute.Executor) error {
+	if errs, _ := execute.ForParallel(ctx, executors); len(errs) > 0 {
 		return execute.CoalesceErrors(errs)
 	}
 	return nil
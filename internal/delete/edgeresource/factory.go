@@ -14,6 +14,7 @@
 package deleteedgeresource
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
@@ -31,7 +32,7 @@ func (exe executor) GetName() string {
 	return fmt.Sprintf("%s/%s", exe.name, exe.version)
 }
 
-func (exe executor) Execute() (err error) {
+func (exe executor) Execute(ctx context.Context) (err error) {
 	if _, err = config.GetNamespace(exe.namespace); err != nil {
 		return
 	}
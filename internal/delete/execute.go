@@ -14,7 +14,9 @@
 package delete
 
 import (
+	"context"
 	"fmt"
+	"sort"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	deleteagent "github.com/eclipse-iofog/iofogctl/v3/internal/delete/agent"
@@ -28,12 +30,13 @@ import (
 	deleteregistry "github.com/eclipse-iofog/iofogctl/v3/internal/delete/registry"
 	deletevolume "github.com/eclipse-iofog/iofogctl/v3/internal/delete/volume"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/plugin"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
 type Options struct {
 	Namespace string
-	InputFile string
+	Input     execute.InputOptions
 	Soft      bool
 }
 
@@ -89,22 +92,34 @@ var kindHandlers = map[config.Kind]func(*execute.KindHandlerOpt) (execute.Execut
 	config.VolumeKind: func(opt *execute.KindHandlerOpt) (exe execute.Executor, err error) {
 		return deletevolume.NewExecutor(opt.Namespace, opt.Name)
 	},
+	execute.OtherKinds: plugin.NewKindHandler(plugin.DeleteVerb),
 }
 
-func Execute(opt *Options) error {
-	executorsMap, err := execute.GetExecutorsFromYAML(opt.InputFile, opt.Namespace, kindHandlers)
+func Execute(ctx context.Context, opt *Options) error {
+	executorsMap, err := execute.GetExecutorsFromYAML(&opt.Input, opt.Namespace, kindHandlers)
 	if err != nil {
 		return err
 	}
 
+	// Documents handled by plugins first, as they can refer to any other document
+	pluginKinds := []config.Kind{}
+	for kind := range executorsMap {
+		if _, found := kindHandlers[kind]; !found {
+			pluginKinds = append(pluginKinds, kind)
+		}
+	}
+	sort.Slice(pluginKinds, func(i, j int) bool {
+		return pluginKinds[i] < pluginKinds[j]
+	})
+
 	// Microservice, Application, Agent, Controller, ControlPlane
-	for idx := range kindOrder {
-		if errs := execute.RunExecutors(executorsMap[kindOrder[idx]], fmt.Sprintf("delete %s", kindOrder[idx])); len(errs) > 0 {
+	for _, kind := range append(pluginKinds, kindOrder...) {
+		if errs := execute.RunExecutors(ctx, executorsMap[kind], fmt.Sprintf("delete %s", kind)); len(errs) > 0 {
 			for _, err := range errs {
 				if _, ok := err.(*util.NotFoundError); !ok {
 					return execute.CoalesceErrors(errs)
 				}
-				util.PrintNotify(fmt.Sprintf("Warning: %s %s.", kindOrder[idx], err.Error()))
+				util.PrintNotify(fmt.Sprintf("Warning: %s %s.", kind, err.Error()))
 			}
 		}
 	}
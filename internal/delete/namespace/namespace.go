@@ -14,12 +14,14 @@
 package deletemicroservice
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	delete "github.com/eclipse-iofog/iofogctl/v3/internal/delete/all"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
-func Execute(name string, force bool) error {
+func Execute(ctx context.Context, name string, force bool) error {
 	// Disallow deletion of default
 	if name == "default" {
 		return util.NewInputError("Cannot delete namespace named \"default\"")
@@ -42,7 +44,7 @@ func Execute(name string, force bool) error {
 
 	// Handle delete all
 	if force && (hasAgents || hasControllers) {
-		if err := delete.Execute(name, false, force); err != nil {
+		if err := delete.Execute(ctx, name, false, force); err != nil {
 			return err
 		}
 	}
@@ -14,6 +14,7 @@
 package deleteregistry
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
@@ -45,7 +46,7 @@ func (exe *Executor) GetName() string {
 }
 
 // Execute deletes application by deleting its associated flow
-func (exe *Executor) Execute() error {
+func (exe *Executor) Execute(ctx context.Context) error {
 	util.SpinStart("Deleting Registry")
 	// Init remote resources
 	clt, err := clientutil.NewControllerClient(exe.namespace)
@@ -14,6 +14,8 @@
 package deleteroute
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
@@ -28,7 +30,7 @@ func (exe executor) GetName() string {
 	return "deleting Route " + exe.name
 }
 
-func (exe executor) Execute() (err error) {
+func (exe executor) Execute(ctx context.Context) (err error) {
 	if _, err = config.GetNamespace(exe.namespace); err != nil {
 		return
 	}
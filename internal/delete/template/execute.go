@@ -14,18 +14,20 @@
 package deleteapplicationtemplate
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
-func Execute(namespace, name string) error {
+func Execute(ctx context.Context, namespace, name string) error {
 	// Get executor
 	exe := NewExecutor(namespace, name)
 
 	// Execute deletion
-	if err := exe.Execute(); err != nil {
+	if err := exe.Execute(ctx); err != nil {
 		return err
 	}
 
@@ -53,7 +55,7 @@ func (exe *Executor) GetName() string {
 }
 
 // Execute deletes application by deleting its associated flow
-func (exe *Executor) Execute() error {
+func (exe *Executor) Execute(ctx context.Context) error {
 	util.SpinStart("Deleting Application Template")
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
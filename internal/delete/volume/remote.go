@@ -14,11 +14,13 @@
 package deletevolume
 
 import (
+	"context"
+
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
-func deleteRemote(agent *rsc.RemoteAgent, volume *rsc.Volume) error {
+func deleteRemote(ctx context.Context, agent *rsc.RemoteAgent, volume *rsc.Volume) error {
 	// Check SSH details
 	if err := agent.ValidateSSH(); err != nil {
 		return err
@@ -28,10 +30,11 @@ func deleteRemote(agent *rsc.RemoteAgent, volume *rsc.Volume) error {
 		return util.NewError("Volume deletion is not supported for local Agents")
 	}
 	// Connect
-	ssh, err := util.NewSecureShellClient(agent.SSH.User, agent.Host, agent.SSH.KeyFile)
+	ssh, err := agent.SSH.NewClient(agent.Host)
 	if err != nil {
 		return err
 	}
+	ssh.SetContext(ctx)
 	if err := ssh.Connect(); err != nil {
 		return err
 	}
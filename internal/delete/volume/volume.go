@@ -14,6 +14,8 @@
 package deletevolume
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
@@ -44,7 +46,7 @@ func (exe *Executor) GetName() string {
 }
 
 // Execute deletes application by deleting its associated flow
-func (exe *Executor) Execute() error {
+func (exe *Executor) Execute(ctx context.Context) error {
 	util.SpinStart("Deleting Volume")
 	volume, err := exe.ns.GetVolume(exe.volumeName)
 	if err != nil {
@@ -54,7 +56,7 @@ func (exe *Executor) Execute() error {
 	// Delete files
 	ch := make(chan error, len(volume.Agents))
 	for idx := range volume.Agents {
-		go exe.execute(&volume, idx, ch)
+		go exe.execute(ctx, &volume, idx, ch)
 	}
 	for idx := 0; idx < len(volume.Agents); idx++ {
 		if err := <-ch; err != nil {
@@ -70,7 +72,7 @@ func (exe *Executor) Execute() error {
 }
 
 // TODO: Parallelize this
-func (exe *Executor) execute(volume *rsc.Volume, agentIdx int, ch chan error) {
+func (exe *Executor) execute(ctx context.Context, volume *rsc.Volume, agentIdx int, ch chan error) {
 	agentName := volume.Agents[agentIdx]
 	baseAgent, err := exe.ns.GetAgent(agentName)
 	if err != nil {
@@ -78,7 +80,7 @@ func (exe *Executor) execute(volume *rsc.Volume, agentIdx int, ch chan error) {
 	}
 	agent, ok := baseAgent.(*rsc.RemoteAgent)
 	if ok {
-		if err = deleteRemote(agent, volume); err != nil {
+		if err = deleteRemote(ctx, agent, volume); err != nil {
 			ch <- err
 		}
 	} else {
@@ -14,10 +14,12 @@
 package deployagent
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	agentconfig "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/agentconfig"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/diff"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog/install"
@@ -46,7 +48,7 @@ func (facade *facadeExecutor) GetTags() *[]string {
 	return facade.tags
 }
 
-func (facade *facadeExecutor) Execute() (err error) {
+func (facade *facadeExecutor) Execute(ctx context.Context) (err error) {
 	// Check the namespace exists
 	ns, err := config.GetNamespace(facade.namespace)
 	if err != nil {
@@ -66,7 +68,7 @@ func (facade *facadeExecutor) Execute() (err error) {
 		util.SpinStart(fmt.Sprintf("Deploying agent %s", facade.GetName()))
 	}
 
-	if err = facade.exe.Execute(); err != nil {
+	if err = facade.exe.Execute(ctx); err != nil {
 		return
 	}
 
@@ -80,7 +82,7 @@ func (facade *facadeExecutor) Execute() (err error) {
 	// Set Agent configuration if provided
 	if agentConfig := facade.agent.GetConfig(); agentConfig != nil {
 		configExe := agentconfig.NewRemoteExecutor(facade.agent.GetName(), agentConfig, facade.namespace, facade.tags)
-		if err := configExe.Execute(); err != nil {
+		if err := configExe.Execute(ctx); err != nil {
 			return err
 		}
 	}
@@ -88,17 +90,37 @@ func (facade *facadeExecutor) Execute() (err error) {
 	return config.Flush()
 }
 
+func (facade *facadeExecutor) Plan() ([]execute.Change, error) {
+	ns, err := config.GetNamespace(facade.namespace)
+	if err != nil {
+		return nil, err
+	}
+	detail := fmt.Sprintf("Install Agent on %s", facade.agent.GetHost())
+	if _, err := ns.GetAgent(facade.agent.GetName()); err != nil {
+		return []execute.Change{{Name: facade.agent.GetName(), Action: execute.CreateAction, Detail: detail}}, nil
+	}
+	kind := config.RemoteAgentKind
+	if _, ok := facade.agent.(*rsc.LocalAgent); ok {
+		kind = config.LocalAgentKind
+	}
+	upToDate, err := diff.IsUpToDate(kind, facade.namespace, facade.agent.GetName(), facade.agent)
+	if err != nil {
+		return nil, err
+	}
+	return []execute.Change{{Name: facade.agent.GetName(), Action: execute.GetUpdateAction(upToDate), Detail: detail}}, nil
+}
+
 func (facade *facadeExecutor) GetName() string {
 	return facade.exe.GetName()
 }
 
-func (facade *facadeExecutor) ProvisionAgent() (string, error) {
+func (facade *facadeExecutor) ProvisionAgent(ctx context.Context) (string, error) {
 	// Required for attach
 	provisionExecutor, ok := facade.exe.(execute.ProvisioningExecutor)
 	if !ok {
 		return "", util.NewInternalError("Facade executor: Could not convert executor")
 	}
-	return provisionExecutor.ProvisionAgent()
+	return provisionExecutor.ProvisionAgent(ctx)
 }
 
 func newFacadeExecutor(exe execute.Executor, namespace string, agent rsc.Agent, isSystem bool, tags *[]string) execute.Executor {
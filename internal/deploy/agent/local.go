@@ -14,6 +14,7 @@
 package deployagent
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 
@@ -55,8 +56,9 @@ func newLocalExecutor(namespace string, agent *rsc.LocalAgent, isSystem bool) (*
 	}, nil
 }
 
-func (exe *localExecutor) ProvisionAgent() (string, error) {
+func (exe *localExecutor) ProvisionAgent(ctx context.Context) (string, error) {
 	// Get agent
+	exe.client.SetContext(ctx)
 	agent := install.NewLocalAgent(exe.localAgentConfig, exe.client)
 
 	// Get user
@@ -87,7 +89,9 @@ func (exe *localExecutor) GetName() string {
 	return exe.agent.Name
 }
 
-func (exe *localExecutor) Execute() error {
+func (exe *localExecutor) Execute(ctx context.Context) error {
+	exe.client.SetContext(ctx)
+
 	// Deploy agent image
 	util.SpinStart("Deploying Agent container")
 	if exe.agent.Container.Image == "" {
@@ -122,7 +126,7 @@ func (exe *localExecutor) Execute() error {
 
 	// Provision agent
 	util.SpinStart("Provisioning Agent")
-	uuid, err := exe.ProvisionAgent()
+	uuid, err := exe.ProvisionAgent(ctx)
 	if err != nil {
 		if cleanErr := exe.client.CleanContainer(agentContainerName); cleanErr != nil {
 			util.PrintNotify(fmt.Sprintf("Could not clean container: %v", agentContainerName))
@@ -14,6 +14,7 @@
 package deployagent
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
@@ -40,17 +41,21 @@ func (exe *remoteExecutor) GetName() string {
 	return exe.agent.Name
 }
 
-func (exe *remoteExecutor) ProvisionAgent() (string, error) {
+func (exe *remoteExecutor) ProvisionAgent(ctx context.Context) (string, error) {
 	// Get agent
 	agent, err := install.NewRemoteAgent(exe.agent.SSH.User,
 		exe.agent.Host,
 		exe.agent.SSH.Port,
-		exe.agent.SSH.KeyFile,
+		exe.agent.SSH.GetAuth(),
 		exe.agent.Name,
 		exe.agent.UUID)
 	if err != nil {
 		return "", err
 	}
+	agent.SetContext(ctx)
+	agent.SetHostKey(exe.agent.SSH.HostKey)
+	agent.SetJumpHosts(exe.agent.SSH.GetJumpHosts())
+	agent.SetPrivilege(exe.agent.SSH.GetPrivilege())
 
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
@@ -76,7 +81,7 @@ func (exe *remoteExecutor) ProvisionAgent() (string, error) {
 }
 
 // Deploy iofog-agent stack on an agent host
-func (exe *remoteExecutor) Execute() (err error) {
+func (exe *remoteExecutor) Execute(ctx context.Context) (err error) {
 	// Get Control Plane
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
@@ -92,12 +97,16 @@ func (exe *remoteExecutor) Execute() (err error) {
 	agent, err := install.NewRemoteAgent(exe.agent.SSH.User,
 		exe.agent.Host,
 		exe.agent.SSH.Port,
-		exe.agent.SSH.KeyFile,
+		exe.agent.SSH.GetAuth(),
 		exe.agent.Name,
 		exe.agent.UUID)
 	if err != nil {
 		return err
 	}
+	agent.SetContext(ctx)
+	agent.SetHostKey(exe.agent.SSH.HostKey)
+	agent.SetJumpHosts(exe.agent.SSH.GetJumpHosts())
+	agent.SetPrivilege(exe.agent.SSH.GetPrivilege())
 
 	// Set custom scripts
 	if exe.agent.Scripts != nil {
@@ -118,7 +127,7 @@ func (exe *remoteExecutor) Execute() (err error) {
 		return
 	}
 
-	uuid, err := exe.ProvisionAgent()
+	uuid, err := exe.ProvisionAgent(ctx)
 	if err != nil {
 		return err
 	}
@@ -136,8 +145,9 @@ func ValidateRemoteAgent(agent *rsc.RemoteAgent) error {
 	if agent.Name == iofog.VanillaRouterAgentName {
 		return util.NewInputError(fmt.Sprintf("%s is a reserved name and cannot be used for an Agent", iofog.VanillaRouterAgentName))
 	}
-	if (agent.Host != "localhost" && agent.Host != "127.0.0.1") && (agent.Host == "" || agent.SSH.User == "" || agent.SSH.KeyFile == "") {
-		return util.NewInputError("For Agents you must specify non-empty values for host, user, and keyfile")
+	// The user, port and authentication can be read from ~/.ssh/config
+	if agent.Host == "" {
+		return util.NewInputError("For Agents you must specify a non-empty value for host")
 	}
 	return nil
 }
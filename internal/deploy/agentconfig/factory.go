@@ -14,6 +14,7 @@
 package deployagentconfig
 
 import (
+	"context"
 	"fmt"
 	"net/url"
 	"strings"
@@ -117,7 +118,7 @@ func isOverridingSystemAgent(controllerHost, agentHost string, isSystem bool) (e
 	return nil
 }
 
-func (exe *RemoteExecutor) Execute() error {
+func (exe *RemoteExecutor) Execute(ctx context.Context) error {
 	isSystem := iutil.IsSystemAgent(exe.agentConfig)
 	if !isSystem || install.IsVerbose() {
 		util.SpinStart(fmt.Sprintf("Deploying agent %s configuration", exe.GetName()))
@@ -182,6 +183,44 @@ func (exe *RemoteExecutor) Execute() error {
 	return updateAgentConfiguration(exe.agentConfig, exe.tags, agent.UUID, clt)
 }
 
+func (exe *RemoteExecutor) Plan() ([]execute.Change, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		if rsc.IsNoControlPlaneError(err) {
+			return []execute.Change{{Name: exe.name, Action: execute.CreateAction}}, nil
+		}
+		return nil, err
+	}
+
+	agent, err := clt.GetAgentByName(exe.name, iutil.IsSystemAgent(exe.agentConfig))
+	if err != nil {
+		if util.IsNotFoundError(err) {
+			return []execute.Change{{Name: exe.name, Action: execute.CreateAction}}, nil
+		}
+		return nil, err
+	}
+	agentList, err := clt.ListAgents(client.ListAgentsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	// Translate router names on a copy to leave the executor untouched
+	agentConfig := *exe.agentConfig
+	if err := Process(&agentConfig, exe.name, agent.IPAddressExternal, agentList.Agents); err != nil {
+		if util.IsNotFoundError(err) {
+			return []execute.Change{{Name: exe.name, Action: execute.UpdateAction, Detail: "Depends on Agents which are not deployed yet"}}, nil
+		}
+		return nil, err
+	}
+	upToDate, err := isAgentUpToDate(&agentConfig, exe.tags, agent)
+	if err != nil {
+		return nil, err
+	}
+	if upToDate {
+		return []execute.Change{{Name: exe.name, Action: execute.NoOpAction}}, nil
+	}
+	return []execute.Change{{Name: exe.name, Action: execute.UpdateAction}}, nil
+}
+
 func NewExecutor(opt Options) (exe execute.Executor, err error) {
 	// Unmarshal file
 	agentConfig := rsc.AgentConfiguration{}
@@ -14,7 +14,9 @@
 package deployagentconfig
 
 import (
+	"encoding/json"
 	"fmt"
+	"reflect"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
@@ -154,3 +156,49 @@ func updateAgentConfiguration(agentConfig *rsc.AgentConfiguration, tags *[]strin
 	}
 	return nil
 }
+
+// isAgentUpToDate returns true if every field set in the configuration matches the Agent on Controller
+func isAgentUpToDate(agentConfig *rsc.AgentConfiguration, tags *[]string, agent *client.AgentInfo) (bool, error) {
+	request := getAgentUpdateRequestFromAgentConfig(agentConfig, tags)
+	if request.FogType != nil && *request.FogType != 0 && *request.FogType != int64(agent.FogType) {
+		return false, nil
+	}
+	if request.Tags != nil && !reflect.DeepEqual(*request.Tags, getTags(agent.Tags)) {
+		return false, nil
+	}
+	// Fields which have a different representation in the Agent info
+	request.Name = ""
+	request.FogType = nil
+	request.Tags = nil
+
+	requested, err := toJSONMap(request)
+	if err != nil {
+		return false, err
+	}
+	current, err := toJSONMap(agent)
+	if err != nil {
+		return false, err
+	}
+	for key, value := range requested {
+		if !reflect.DeepEqual(value, current[key]) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+func getTags(tags *[]string) []string {
+	if tags == nil {
+		return []string{}
+	}
+	return *tags
+}
+
+func toJSONMap(in interface{}) (out map[string]interface{}, err error) {
+	bytes, err := json.Marshal(in)
+	if err != nil {
+		return
+	}
+	err = json.Unmarshal(bytes, &out)
+	return
+}
@@ -0,0 +1,71 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deployagentconfig
+
+import (
+	"testing"
+
+	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
+	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
+)
+
+func TestIsAgentUpToDate(t *testing.T) {
+	host := "10.0.0.1"
+	memoryLimit := int64(4096)
+	fogType := "x86"
+	agentConfig := &rsc.AgentConfiguration{
+		Name:        "agent-1",
+		Description: "edge node",
+		FogType:     &fogType,
+		AgentConfiguration: client.AgentConfiguration{
+			Host:        &host,
+			MemoryLimit: &memoryLimit,
+		},
+	}
+	agent := &client.AgentInfo{
+		Name:        "agent-1",
+		Host:        host,
+		Description: "edge node",
+		MemoryLimit: memoryLimit,
+		CPULimit:    80,
+		FogType:     1,
+	}
+
+	upToDate, err := isAgentUpToDate(agentConfig, nil, agent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !upToDate {
+		t.Error("Expected Agent to be up to date")
+	}
+
+	agent.MemoryLimit = 2048
+	upToDate, err = isAgentUpToDate(agentConfig, nil, agent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upToDate {
+		t.Error("Expected Agent with different memory limit to require an update")
+	}
+
+	agent.MemoryLimit = memoryLimit
+	tags := []string{"gpu"}
+	upToDate, err = isAgentUpToDate(agentConfig, &tags, agent)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if upToDate {
+		t.Error("Expected Agent with different tags to require an update")
+	}
+}
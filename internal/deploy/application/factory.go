@@ -14,11 +14,14 @@
 package deployapplication
 
 import (
+	"context"
 	"fmt"
 
 	apps "github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/apps"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/diff"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 	"gopkg.in/yaml.v2"
@@ -34,13 +37,21 @@ type remoteExecutor struct {
 	namespace   string
 	application interface{}
 	name        string
+	msvcNames   []string
+	// Spec of each Microservice, by name
+	msvcSpecs map[string]interface{}
+}
+
+// applicationSpec contains the fields of an Application required to plan its deployment
+type applicationSpec struct {
+	Microservices []map[string]interface{} `yaml:"microservices"`
 }
 
 func (exe *remoteExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *remoteExecutor) Execute() error {
+func (exe *remoteExecutor) Execute(ctx context.Context) error {
 	util.SpinStart(fmt.Sprintf("Deploying Application %s", exe.GetName()))
 
 	ns, err := config.GetNamespace(exe.namespace)
@@ -76,6 +87,87 @@ func (exe *remoteExecutor) Execute() error {
 	return apps.DeployApplication(controller, exe.application, exe.name)
 }
 
+func (exe *remoteExecutor) Plan() ([]execute.Change, error) {
+	changes := []execute.Change{}
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		if rsc.IsNoControlPlaneError(err) {
+			return append(changes, exe.newChanges(execute.CreateAction, "")...), nil
+		}
+		return nil, err
+	}
+
+	if _, err := clt.GetApplicationByName(exe.name); err != nil {
+		if util.IsNotFoundError(err) {
+			return append(changes, exe.newChanges(execute.CreateAction, "")...), nil
+		}
+		return nil, err
+	}
+
+	// Application exists, compare it and its Microservices with the requested ones
+	upToDate, err := diff.IsUpToDate(config.ApplicationKind, exe.namespace, exe.name, exe.application)
+	if err != nil {
+		return nil, err
+	}
+	msvcListResponse, err := clt.GetMicroservicesByApplication(exe.name)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]bool)
+	for idx := range msvcListResponse.Microservices {
+		msvc := &msvcListResponse.Microservices[idx]
+		if util.IsSystemMsvc(msvc) {
+			continue
+		}
+		existing[msvc.Name] = true
+	}
+	requested := make(map[string]bool)
+	changes = append(changes, execute.Change{Name: exe.name, Action: execute.GetUpdateAction(upToDate)})
+	for _, msvcName := range exe.msvcNames {
+		requested[msvcName] = true
+		action := execute.CreateAction
+		if existing[msvcName] {
+			msvcUpToDate := upToDate
+			if !msvcUpToDate {
+				if msvcUpToDate, err = diff.IsUpToDate(config.MicroserviceKind, exe.namespace, exe.name+"/"+msvcName, exe.msvcSpecs[msvcName]); err != nil {
+					return nil, err
+				}
+			}
+			action = execute.GetUpdateAction(msvcUpToDate)
+		}
+		changes = append(changes, execute.Change{
+			Kind:   config.MicroserviceKind,
+			Name:   exe.name + "/" + msvcName,
+			Action: action,
+		})
+	}
+	for idx := range msvcListResponse.Microservices {
+		msvc := &msvcListResponse.Microservices[idx]
+		if !existing[msvc.Name] || requested[msvc.Name] {
+			continue
+		}
+		changes = append(changes, execute.Change{
+			Kind:   config.MicroserviceKind,
+			Name:   exe.name + "/" + msvc.Name,
+			Action: execute.DeleteAction,
+			Detail: "Not specified in Application",
+		})
+	}
+	return changes, nil
+}
+
+func (exe *remoteExecutor) newChanges(action execute.Action, detail string) (changes []execute.Change) {
+	changes = append(changes, execute.Change{Name: exe.name, Action: action, Detail: detail})
+	for _, msvcName := range exe.msvcNames {
+		changes = append(changes, execute.Change{
+			Kind:   config.MicroserviceKind,
+			Name:   exe.name + "/" + msvcName,
+			Action: action,
+		})
+	}
+	return
+}
+
 func NewExecutor(opt Options) (exe execute.Executor, err error) {
 	// Check the namespace exists
 	if _, err = config.GetNamespace(opt.Namespace); err != nil {
@@ -87,10 +179,24 @@ func NewExecutor(opt Options) (exe execute.Executor, err error) {
 		err = util.NewUnmarshalError(err.Error())
 		return
 	}
+	var spec applicationSpec
+	if err = yaml.Unmarshal(opt.Yaml, &spec); err != nil {
+		err = util.NewUnmarshalError(err.Error())
+		return
+	}
+	msvcNames := []string{}
+	msvcSpecs := make(map[string]interface{})
+	for _, msvc := range spec.Microservices {
+		msvcName, _ := msvc["name"].(string)
+		msvcNames = append(msvcNames, msvcName)
+		msvcSpecs[msvcName] = msvc
+	}
 
 	return &remoteExecutor{
 		namespace:   opt.Namespace,
 		application: &application,
 		name:        opt.Name,
+		msvcNames:   msvcNames,
+		msvcSpecs:   msvcSpecs,
 	}, nil
 }
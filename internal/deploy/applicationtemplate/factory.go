@@ -14,11 +14,13 @@
 package deployapplicationtemplate
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/apps"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 	"gopkg.in/yaml.v2"
@@ -40,7 +42,7 @@ func (exe *remoteExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *remoteExecutor) Execute() error {
+func (exe *remoteExecutor) Execute(ctx context.Context) error {
 	util.SpinStart(fmt.Sprintf("Deploying Application Template %s", exe.GetName()))
 
 	ns, err := config.GetNamespace(exe.namespace)
@@ -81,6 +83,24 @@ func (exe *remoteExecutor) Execute() error {
 	return apps.DeployApplicationTemplate(controller, baseURL, exe.template, exe.name)
 }
 
+func (exe *remoteExecutor) Plan() ([]execute.Change, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		if rsc.IsNoControlPlaneError(err) {
+			return []execute.Change{{Name: exe.name, Action: execute.CreateAction}}, nil
+		}
+		return nil, err
+	}
+
+	if _, err := clt.GetApplicationTemplate(exe.name); err != nil {
+		if util.IsNotFoundError(err) {
+			return []execute.Change{{Name: exe.name, Action: execute.CreateAction}}, nil
+		}
+		return nil, err
+	}
+	return []execute.Change{{Name: exe.name, Action: execute.UpdateAction}}, nil
+}
+
 func NewExecutor(opt Options) (exe execute.Executor, err error) {
 	// Check the namespace exists
 	if _, err = config.GetNamespace(opt.Namespace); err != nil {
@@ -0,0 +1,335 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	deleteapplication "github.com/eclipse-iofog/iofogctl/v3/internal/delete/application"
+	deleteedgeresource "github.com/eclipse-iofog/iofogctl/v3/internal/delete/edgeresource"
+	deletemicroservice "github.com/eclipse-iofog/iofogctl/v3/internal/delete/microservice"
+	deleteregistry "github.com/eclipse-iofog/iofogctl/v3/internal/delete/registry"
+	deleteroute "github.com/eclipse-iofog/iofogctl/v3/internal/delete/route"
+	deletetemplate "github.com/eclipse-iofog/iofogctl/v3/internal/delete/template"
+	deletevolume "github.com/eclipse-iofog/iofogctl/v3/internal/delete/volume"
+	deployagentconfig "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/agentconfig"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/describe"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
+	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// atomicKinds are the kinds rolled back by --atomic, in the order they are deployed
+var atomicKinds = []config.Kind{
+	config.AgentConfigKind,
+	config.EdgeResourceKind,
+	config.ApplicationTemplateKind,
+	config.VolumeKind,
+	config.RegistryKind,
+	config.ApplicationKind,
+	config.MicroserviceKind,
+	config.RouteKind,
+}
+
+// attemptExecutor records whether the executor it wraps was run
+type attemptExecutor struct {
+	execute.Executor
+	attempted bool
+}
+
+func (exe *attemptExecutor) Execute(ctx context.Context) error {
+	exe.attempted = true
+	return exe.Executor.Execute(ctx)
+}
+
+// attemptAgentConfigExecutor records whether the Agent configuration executor it wraps was run,
+// and still exposes its configuration to order the Agent configurations
+type attemptAgentConfigExecutor struct {
+	deployagentconfig.AgentConfigExecutor
+	attempt *attemptExecutor
+}
+
+func (exe *attemptAgentConfigExecutor) Execute(ctx context.Context) error {
+	return exe.attempt.Execute(ctx)
+}
+
+// snapshot is the state of a resource before it is deployed or pruned
+type snapshot struct {
+	kind   config.Kind
+	name   string
+	spec   map[string]interface{}
+	pruned bool
+	// live is nil if the resource did not exist
+	live *config.Header
+	// registryIDs are the IDs of the Registries which existed with the same URL
+	registryIDs map[int]bool
+	// exe is the executor deploying or pruning the resource
+	exe *attemptExecutor
+}
+
+func (snap *snapshot) isAttempted() bool {
+	return snap.exe.attempted
+}
+
+// takeSnapshots describes the resources affected by the deployment and wraps their executors to track which ones are run
+func takeSnapshots(namespace string, documents []*document, agentConfigExecutors []execute.Executor, pruneTargets []pruneTarget) (snapshots []*snapshot, agentAttempts map[string]*attemptExecutor, err error) {
+	isAtomicKind := make(map[config.Kind]bool)
+	for _, kind := range atomicKinds {
+		isAtomicKind[kind] = true
+	}
+
+	// Agent Configurations are deployed separately from their documents
+	for idx := range agentConfigExecutors {
+		configExe, ok := agentConfigExecutors[idx].(deployagentconfig.AgentConfigExecutor)
+		if !ok {
+			return nil, nil, util.NewInternalError("Could not convert agent config executor")
+		}
+		attemptExe := &attemptExecutor{Executor: configExe}
+		snapshots = append(snapshots, &snapshot{
+			kind: config.AgentConfigKind,
+			name: configExe.GetName(),
+			exe:  attemptExe,
+		})
+		agentConfigExecutors[idx] = &attemptAgentConfigExecutor{AgentConfigExecutor: configExe, attempt: attemptExe}
+	}
+	agentAttempts = make(map[string]*attemptExecutor)
+	for _, doc := range documents {
+		attemptExe := &attemptExecutor{Executor: doc.exe}
+		switch {
+		case doc.kind == config.RemoteAgentKind || doc.kind == config.LocalAgentKind:
+			// Agents are not rolled back, but their configuration is kept if they are installed
+			agentAttempts[doc.name] = attemptExe
+		case doc.kind == config.AgentConfigKind || !isAtomicKind[doc.kind]:
+			continue
+		default:
+			spec := make(map[string]interface{})
+			if err = yaml.Unmarshal(doc.yaml, &spec); err != nil {
+				return nil, nil, util.NewUnmarshalError(err.Error())
+			}
+			snapshots = append(snapshots, &snapshot{
+				kind: doc.kind,
+				name: doc.name,
+				spec: spec,
+				exe:  attemptExe,
+			})
+		}
+		doc.exe = attemptExe
+	}
+	for idx := range pruneTargets {
+		attemptExe := &attemptExecutor{Executor: pruneTargets[idx].exe}
+		snapshots = append(snapshots, &snapshot{
+			kind:   pruneTargets[idx].kind,
+			name:   pruneTargets[idx].docName,
+			spec:   pruneTargets[idx].docSpec,
+			pruned: true,
+			exe:    attemptExe,
+		})
+		pruneTargets[idx].exe = attemptExe
+	}
+
+	// Describe the agents known by the Controller
+	if len(agentConfigExecutors) > 0 {
+		if err = clientutil.SyncAgentInfo(namespace); err != nil && !rsc.IsNoControlPlaneError(err) {
+			return
+		}
+	}
+
+	// Describe all resources in parallel
+	var wg sync.WaitGroup
+	errs := make([]error, len(snapshots))
+	for idx := range snapshots {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			errs[idx] = snapshots[idx].take(namespace)
+		}(idx)
+	}
+	wg.Wait()
+	for idx := range errs {
+		if errs[idx] != nil {
+			return nil, nil, errs[idx]
+		}
+	}
+	return snapshots, agentAttempts, nil
+}
+
+func (snap *snapshot) take(namespace string) (err error) {
+	if snap.live, err = describe.GetDocumentHeader(snap.kind, namespace, snap.name, snap.spec); err != nil {
+		return err
+	}
+	if snap.kind != config.RegistryKind || snap.live != nil {
+		return nil
+	}
+
+	// Registries are identified by URL until they are created
+	snap.registryIDs = make(map[int]bool)
+	clt, err := clientutil.NewControllerClient(namespace)
+	if err != nil {
+		if rsc.IsNoControlPlaneError(err) {
+			return nil
+		}
+		return err
+	}
+	registries, err := clt.ListRegistries()
+	if err != nil {
+		return err
+	}
+	for _, registry := range registries.Registries {
+		if registry.URL == fmt.Sprint(snap.spec["url"]) {
+			snap.registryIDs[registry.ID] = true
+		}
+	}
+	return nil
+}
+
+// rollback undoes the changes of the deployment which failed with deployErr.
+// Pruned resources are restored first, then deployed resources are restored or deleted in the reverse order of their deployment
+func rollback(namespace string, snapshots []*snapshot, agentAttempts map[string]*attemptExecutor, deployErr error) error {
+	util.PrintNotify(fmt.Sprintf("Deployment failed, rolling back changes: %s", deployErr.Error()))
+	// Roll back even if the deployment was cancelled
+	ctx := context.Background()
+	errs := []error{}
+	runKind := func(kind config.Kind, pruned bool) {
+		exes := []execute.Executor{}
+		for _, snap := range snapshots {
+			if snap.kind != kind || snap.pruned != pruned || !snap.isAttempted() {
+				continue
+			}
+			// Agents installed by the deployment need their configuration
+			if snap.kind == config.AgentConfigKind && snap.live == nil && agentAttempts[snap.name] != nil && agentAttempts[snap.name].attempted {
+				continue
+			}
+			snapExes, err := snap.getCompensations(namespace)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			exes = append(exes, snapExes...)
+		}
+		for _, err := range execute.RunExecutors(ctx, exes, fmt.Sprintf("roll back %s", kind)) {
+			if !util.IsNotFoundError(err) {
+				errs = append(errs, err)
+			}
+		}
+	}
+	for _, kind := range atomicKinds {
+		runKind(kind, true)
+	}
+	for idx := len(atomicKinds) - 1; idx >= 0; idx-- {
+		runKind(atomicKinds[idx], false)
+	}
+
+	if len(errs) > 0 {
+		return util.NewError(fmt.Sprintf("%s\nFailed to roll back all changes:\n%s", deployErr.Error(), execute.CoalesceErrors(errs).Error()))
+	}
+	util.PrintNotify("Rolled back all changes")
+	return deployErr
+}
+
+// getCompensations returns the executors restoring the resource to its snapshot
+func (snap *snapshot) getCompensations(namespace string) ([]execute.Executor, error) {
+	// Redeploy the previous spec
+	if snap.live != nil {
+		yamlBytes, err := yaml.Marshal(snap.live.Spec)
+		if err != nil {
+			return nil, err
+		}
+		exe, err := kindHandlers[snap.kind](&execute.KindHandlerOpt{
+			Kind:      snap.kind,
+			Namespace: namespace,
+			Name:      snap.live.Metadata.Name,
+			YAML:      yamlBytes,
+			Tags:      snap.live.Metadata.Tags,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return []execute.Executor{exe}, nil
+	}
+
+	// Delete the created resource
+	switch snap.kind {
+	case config.AgentConfigKind:
+		return []execute.Executor{&deleteAgentConfigExecutor{namespace: namespace, name: snap.name}}, nil
+	case config.EdgeResourceKind:
+		return []execute.Executor{deleteedgeresource.NewExecutor(namespace, snap.name, fmt.Sprint(snap.spec["version"]))}, nil
+	case config.ApplicationTemplateKind:
+		return []execute.Executor{deletetemplate.NewExecutor(namespace, snap.name)}, nil
+	case config.VolumeKind:
+		exe, err := deletevolume.NewExecutor(namespace, snap.name)
+		return []execute.Executor{exe}, err
+	case config.RegistryKind:
+		return snap.getRegistryCompensations(namespace)
+	case config.ApplicationKind:
+		exe, err := deleteapplication.NewExecutor(namespace, snap.name)
+		return []execute.Executor{exe}, err
+	case config.MicroserviceKind:
+		exe, err := deletemicroservice.NewExecutor(namespace, snap.name)
+		return []execute.Executor{exe}, err
+	case config.RouteKind:
+		return []execute.Executor{deleteroute.NewExecutor(namespace, snap.name)}, nil
+	}
+	return nil, util.NewInternalError(fmt.Sprintf("Cannot roll back %s %s", snap.kind, snap.name))
+}
+
+// getRegistryCompensations deletes the Registries with the URL of the snapshot which did not exist before
+func (snap *snapshot) getRegistryCompensations(namespace string) (exes []execute.Executor, err error) {
+	clt, err := clientutil.NewControllerClient(namespace)
+	if err != nil {
+		return
+	}
+	registries, err := clt.ListRegistries()
+	if err != nil {
+		return
+	}
+	for _, registry := range registries.Registries {
+		if registry.URL != fmt.Sprint(snap.spec["url"]) || snap.registryIDs[registry.ID] {
+			continue
+		}
+		exe, err := deleteregistry.NewExecutor(namespace, strconv.Itoa(registry.ID))
+		if err != nil {
+			return nil, err
+		}
+		exes = append(exes, exe)
+	}
+	return exes, nil
+}
+
+// deleteAgentConfigExecutor removes an Agent from the Controller without uninstalling it
+type deleteAgentConfigExecutor struct {
+	namespace string
+	name      string
+}
+
+func (exe *deleteAgentConfigExecutor) GetName() string {
+	return exe.name
+}
+
+func (exe *deleteAgentConfigExecutor) Execute(ctx context.Context) error {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return err
+	}
+	agent, err := clt.GetAgentByName(exe.name, false)
+	if err != nil {
+		return err
+	}
+	return clt.DeleteAgent(agent.UUID)
+}
@@ -0,0 +1,77 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deploy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	deployagentconfig "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/agentconfig"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
+)
+
+func TestSnapshotCompensations(t *testing.T) {
+	snap := &snapshot{
+		kind: config.EdgeResourceKind,
+		name: "edge",
+		spec: map[string]interface{}{"version": "1.0.0"},
+		exe:  &attemptExecutor{Executor: execute.NewEmptyExecutor("edge")},
+	}
+	if snap.isAttempted() {
+		t.Error("Expected snapshot not to be attempted before its executor runs")
+	}
+	if err := snap.exe.Execute(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if !snap.isAttempted() {
+		t.Error("Expected snapshot to be attempted after its executor runs")
+	}
+
+	// Created resources are deleted
+	exes, err := snap.getCompensations("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exes) != 1 || exes[0].GetName() != "edge/1.0.0" {
+		t.Errorf("Expected deletion of edge/1.0.0, got %v", exes)
+	}
+}
+
+func TestAgentConfigSnapshots(t *testing.T) {
+	config.Init(t.TempDir())
+	exes := []execute.Executor{
+		deployagentconfig.NewRemoteExecutor("agent", &rsc.AgentConfiguration{Name: "agent"}, "default", nil),
+	}
+	snapshots, _, err := takeSnapshots("default", nil, exes, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(snapshots) != 1 {
+		t.Fatalf("Expected 1 snapshot, got %d", len(snapshots))
+	}
+	if snapshots[0].isAttempted() {
+		t.Error("Expected Agent config snapshot not to be attempted before its executor runs")
+	}
+	if _, ok := exes[0].(deployagentconfig.AgentConfigExecutor); !ok {
+		t.Fatal("Expected wrapped executor to remain an Agent config executor")
+	}
+
+	// The Agent config cannot be deployed without a Controller, but it has still been attempted
+	_ = exes[0].Execute(context.Background())
+	if !snapshots[0].isAttempted() {
+		t.Error("Expected Agent config snapshot to be attempted after its executor runs")
+	}
+}
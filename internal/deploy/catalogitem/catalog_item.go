@@ -14,6 +14,7 @@
 package deploycatalogitem
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 	"gopkg.in/yaml.v2"
@@ -41,14 +43,9 @@ func (exe *remoteExecutor) GetName() string {
 	return exe.catalogItem.Name
 }
 
-func (exe *remoteExecutor) updateCatalogItem(clt *client.Client) (err error) {
-	currentItem, err := clt.GetCatalogItem(exe.catalogItem.ID)
-	if err != nil {
-		return err
-	}
-
-	request := client.CatalogItemUpdateRequest{
-		ID:          currentItem.ID,
+func (exe *remoteExecutor) getUpdateRequest(id int) (request client.CatalogItemUpdateRequest, err error) {
+	request = client.CatalogItemUpdateRequest{
+		ID:          id,
 		Name:        exe.catalogItem.Name,
 		Images:      []client.CatalogImage{},
 		Description: exe.catalogItem.Description,
@@ -59,7 +56,7 @@ func (exe *remoteExecutor) updateCatalogItem(clt *client.Client) (err error) {
 		if !ok {
 			registryID, err = strconv.Atoi(exe.catalogItem.Registry)
 			if err != nil {
-				return err
+				return
 			}
 		}
 		request.RegistryID = registryID
@@ -78,6 +75,19 @@ func (exe *remoteExecutor) updateCatalogItem(clt *client.Client) (err error) {
 			AgentTypeID:    client.AgentTypeAgentTypeIDDict["arm"],
 		})
 	}
+	return
+}
+
+func (exe *remoteExecutor) updateCatalogItem(clt *client.Client) (err error) {
+	currentItem, err := clt.GetCatalogItem(exe.catalogItem.ID)
+	if err != nil {
+		return err
+	}
+
+	request, err := exe.getUpdateRequest(currentItem.ID)
+	if err != nil {
+		return err
+	}
 
 	if _, err = clt.UpdateCatalogItem(&request); err != nil {
 		return err
@@ -101,7 +111,7 @@ func (exe *remoteExecutor) createCatalogItem(clt *client.Client) (err error) {
 	return nil
 }
 
-func (exe *remoteExecutor) Execute() error {
+func (exe *remoteExecutor) Execute(ctx context.Context) error {
 	util.SpinStart(fmt.Sprintf("Deploying catalog item %s", exe.GetName()))
 	// Init remote resources
 	clt, err := clientutil.NewControllerClient(exe.namespace)
@@ -114,6 +124,58 @@ func (exe *remoteExecutor) Execute() error {
 	return exe.updateCatalogItem(clt)
 }
 
+func (exe *remoteExecutor) Plan() ([]execute.Change, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		if rsc.IsNoControlPlaneError(err) {
+			return []execute.Change{{Name: exe.GetName(), Action: execute.CreateAction}}, nil
+		}
+		return nil, err
+	}
+
+	if exe.catalogItem.ID == 0 {
+		change := execute.Change{Name: exe.GetName(), Action: execute.CreateAction}
+		if currentItem, err := clt.GetCatalogItemByName(exe.GetName()); err == nil {
+			change.Detail = fmt.Sprintf("A Catalog Item with this name already exists with ID %d", currentItem.ID)
+		} else if !util.IsNotFoundError(err) {
+			return nil, err
+		}
+		return []execute.Change{change}, nil
+	}
+
+	currentItem, err := clt.GetCatalogItem(exe.catalogItem.ID)
+	if err != nil {
+		return nil, err
+	}
+	request, err := exe.getUpdateRequest(currentItem.ID)
+	if err != nil {
+		return nil, err
+	}
+	if isCatalogItemUpToDate(currentItem, &request) {
+		return []execute.Change{{Name: exe.GetName(), Action: execute.NoOpAction}}, nil
+	}
+	return []execute.Change{{Name: exe.GetName(), Action: execute.UpdateAction}}, nil
+}
+
+func isCatalogItemUpToDate(currentItem *client.CatalogItemInfo, request *client.CatalogItemUpdateRequest) bool {
+	if currentItem.Name != request.Name || currentItem.Description != request.Description {
+		return false
+	}
+	if request.RegistryID != 0 && currentItem.RegistryID != request.RegistryID {
+		return false
+	}
+	currentImages := make(map[int]string)
+	for _, image := range currentItem.Images {
+		currentImages[image.AgentTypeID] = image.ContainerImage
+	}
+	for _, image := range request.Images {
+		if currentImages[image.AgentTypeID] != image.ContainerImage {
+			return false
+		}
+	}
+	return true
+}
+
 func NewExecutor(opt Options) (exe execute.Executor, err error) {
 	// Check the namespace exists
 	ns, err := config.GetNamespace(opt.Namespace)
@@ -138,7 +200,7 @@ func NewExecutor(opt Options) (exe execute.Executor, err error) {
 	}
 
 	// Validate catalog item definition
-	if err := validate(&catalogItem); err != nil {
+	if err := Validate(&catalogItem); err != nil {
 		return nil, err
 	}
 
@@ -148,7 +210,7 @@ func NewExecutor(opt Options) (exe execute.Executor, err error) {
 	}, nil
 }
 
-func validate(opt *apps.CatalogItem) error {
+func Validate(opt *apps.CatalogItem) error {
 	if opt.Name == "" {
 		return util.NewInputError("Name must be specified")
 	}
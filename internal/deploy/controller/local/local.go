@@ -14,10 +14,12 @@
 package deploylocalcontroller
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/diff"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
@@ -148,7 +150,27 @@ func (exe *localExecutor) GetName() string {
 	return exe.ctrl.Name
 }
 
-func (exe *localExecutor) Execute() error {
+func (exe *localExecutor) Plan() ([]execute.Change, error) {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	detail := fmt.Sprintf("Deploy container %s", exe.localControllerConfig.ContainerName)
+	for _, ctrl := range ns.GetControllers() {
+		if ctrl.GetName() == exe.ctrl.Name {
+			upToDate, err := diff.IsUpToDate(config.LocalControllerKind, exe.namespace, exe.ctrl.Name, exe.ctrl)
+			if err != nil {
+				return nil, err
+			}
+			return []execute.Change{{Name: exe.ctrl.Name, Action: execute.GetUpdateAction(upToDate), Detail: detail}}, nil
+		}
+	}
+	return []execute.Change{{Name: exe.ctrl.Name, Action: execute.CreateAction, Detail: detail}}, nil
+}
+
+func (exe *localExecutor) Execute(ctx context.Context) error {
+	exe.client.SetContext(ctx)
+
 	// Deploy Controller images
 	if err := exe.deployContainers(); err != nil {
 		exe.cleanContainers()
@@ -14,7 +14,11 @@
 package deployremotecontroller
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/diff"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog/install"
@@ -100,7 +104,25 @@ func NewExecutorWithoutParsing(namespace string, controlPlane *rsc.RemoteControl
 	return newExecutor(namespace, controlPlane, controller), nil
 }
 
-func (exe *remoteExecutor) Execute() (err error) {
+func (exe *remoteExecutor) Plan() ([]execute.Change, error) {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	detail := fmt.Sprintf("Install Controller on %s", exe.controller.Host)
+	for _, ctrl := range ns.GetControllers() {
+		if ctrl.GetName() == exe.controller.Name {
+			upToDate, err := diff.IsUpToDate(config.RemoteControllerKind, exe.namespace, exe.controller.Name, exe.controller)
+			if err != nil {
+				return nil, err
+			}
+			return []execute.Change{{Name: exe.controller.Name, Action: execute.GetUpdateAction(upToDate), Detail: detail}}, nil
+		}
+	}
+	return []execute.Change{{Name: exe.controller.Name, Action: execute.CreateAction, Detail: detail}}, nil
+}
+
+func (exe *remoteExecutor) Execute(ctx context.Context) (err error) {
 	if err = exe.controller.ValidateSSH(); err != nil {
 		return
 	}
@@ -110,7 +132,10 @@ func (exe *remoteExecutor) Execute() (err error) {
 		User:                exe.controller.SSH.User,
 		Host:                exe.controller.Host,
 		Port:                exe.controller.SSH.Port,
-		PrivKeyFilename:     exe.controller.SSH.KeyFile,
+		Auth:                exe.controller.SSH.GetAuth(),
+		HostKey:             exe.controller.SSH.HostKey,
+		JumpHosts:           exe.controller.SSH.GetJumpHosts(),
+		Privilege:           exe.controller.SSH.GetPrivilege(),
 		PidBaseDir:          exe.controller.PidBaseDir,
 		EcnViewerPort:       exe.controller.EcnViewerPort,
 		Version:             exe.controlPlane.Package.Version,
@@ -122,6 +147,7 @@ func (exe *remoteExecutor) Execute() (err error) {
 	if err != nil {
 		return err
 	}
+	deployer.SetContext(ctx)
 
 	// Set database configuration
 	if exe.controlPlane.Database.Host != "" {
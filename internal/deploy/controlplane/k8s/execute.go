@@ -14,9 +14,11 @@
 package deployk8scontrolplane
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/diff"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog/install"
@@ -35,9 +37,9 @@ type kubernetesControlPlaneExecutor struct {
 	name         string
 }
 
-func (exe kubernetesControlPlaneExecutor) Execute() (err error) {
+func (exe kubernetesControlPlaneExecutor) Execute(ctx context.Context) (err error) {
 	util.SpinStart(fmt.Sprintf("Deploying controlplane %s", exe.GetName()))
-	if err := exe.executeInstall(); err != nil {
+	if err := exe.executeInstall(ctx); err != nil {
 		return err
 	}
 
@@ -50,6 +52,22 @@ func (exe kubernetesControlPlaneExecutor) Execute() (err error) {
 	return config.Flush()
 }
 
+func (exe kubernetesControlPlaneExecutor) Plan() ([]execute.Change, error) {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	detail := fmt.Sprintf("Apply Control Plane to the cluster of %s", exe.controlPlane.KubeConfig)
+	if _, err := ns.GetControlPlane(); err != nil {
+		return []execute.Change{{Name: exe.name, Action: execute.CreateAction, Detail: detail}}, nil
+	}
+	upToDate, err := diff.IsUpToDate(config.KubernetesControlPlaneKind, exe.namespace, exe.name, exe.controlPlane)
+	if err != nil {
+		return nil, err
+	}
+	return []execute.Change{{Name: exe.name, Action: execute.GetUpdateAction(upToDate), Detail: detail}}, nil
+}
+
 func (exe kubernetesControlPlaneExecutor) GetName() string {
 	return exe.name
 }
@@ -74,19 +92,20 @@ func NewExecutor(opt Options) (exe execute.Executor, err error) {
 	if err != nil {
 		return
 	}
-	if err := validate(&controlPlane); err != nil {
+	if err := Validate(&controlPlane); err != nil {
 		return nil, err
 	}
 
 	return newControlPlaneExecutor(opt.Namespace, opt.Name, &controlPlane), nil
 }
 
-func (exe *kubernetesControlPlaneExecutor) executeInstall() (err error) {
+func (exe *kubernetesControlPlaneExecutor) executeInstall(ctx context.Context) (err error) {
 	// Get Kubernetes deployer
 	installer, err := install.NewKubernetes(exe.controlPlane.KubeConfig, exe.namespace)
 	if err != nil {
 		return
 	}
+	installer.SetContext(ctx)
 
 	// Configure deploy
 	installer.SetOperatorImage(exe.controlPlane.Images.Operator)
@@ -138,7 +157,7 @@ func (exe *kubernetesControlPlaneExecutor) executeInstall() (err error) {
 	return err
 }
 
-func validate(controlPlane *rsc.KubernetesControlPlane) (err error) {
+func Validate(controlPlane *rsc.KubernetesControlPlane) (err error) {
 	// Validate user
 	user := controlPlane.GetUser()
 	if user.Email == "" || user.Name == "" || user.Password == "" || user.Surname == "" {
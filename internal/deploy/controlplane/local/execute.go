@@ -14,6 +14,7 @@
 package deploylocalcontrolplane
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -21,6 +22,7 @@ import (
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	deployagentconfig "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/agentconfig"
 	deploylocalcontroller "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/controller/local"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/diff"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	iutil "github.com/eclipse-iofog/iofogctl/v3/internal/util"
@@ -43,7 +45,7 @@ type localControlPlaneExecutor struct {
 }
 
 // TODO: remove duplication
-func deploySystemAgent(namespace string) (err error) {
+func deploySystemAgent(ctx context.Context, namespace string) (err error) {
 	host := "localhost"
 	// Deploy system agent to host internal router
 	install.Verbose("Deploying system agent")
@@ -66,22 +68,22 @@ func deploySystemAgent(namespace string) (err error) {
 	// Get Agentconfig executor
 	deployAgentConfigExecutor := deployagentconfig.NewRemoteExecutor(iofog.VanillaRouterAgentName, &deployAgentConfig, namespace, nil)
 	// If there already is a system fog, ignore error
-	if err := deployAgentConfigExecutor.Execute(); err != nil {
+	if err := deployAgentConfigExecutor.Execute(ctx); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (exe localControlPlaneExecutor) postDeploy() (err error) {
-	if err := deploySystemAgent(exe.namespace); err != nil {
+func (exe localControlPlaneExecutor) postDeploy(ctx context.Context) (err error) {
+	if err := deploySystemAgent(ctx, exe.namespace); err != nil {
 		return err
 	}
 	return nil
 }
 
-func (exe localControlPlaneExecutor) Execute() (err error) {
+func (exe localControlPlaneExecutor) Execute(ctx context.Context) (err error) {
 	util.SpinStart(fmt.Sprintf("Deploying controlplane %s", exe.GetName()))
-	if err := runExecutors(exe.controllerExecutors); err != nil {
+	if err := runExecutors(ctx, exe.controllerExecutors); err != nil {
 		return err
 	}
 
@@ -92,7 +94,7 @@ func (exe localControlPlaneExecutor) Execute() (err error) {
 	}
 	endpoint := controller.GetEndpoint()
 
-	if err := install.WaitForControllerAPI(endpoint); err != nil {
+	if err := install.WaitForControllerAPI(ctx, endpoint); err != nil {
 		return err
 	}
 	// Create new user
@@ -126,7 +128,19 @@ func (exe localControlPlaneExecutor) Execute() (err error) {
 		return err
 	}
 	// Post deploy steps
-	return exe.postDeploy()
+	return exe.postDeploy(ctx)
+}
+
+func (exe localControlPlaneExecutor) Plan() ([]execute.Change, error) {
+	changes, err := planControlPlane(exe.namespace, exe.name, exe.controlPlane)
+	if err != nil {
+		return nil, err
+	}
+	controllerChanges, errs := execute.PlanExecutors(exe.controllerExecutors, config.LocalControllerKind)
+	if len(errs) > 0 {
+		return nil, execute.CoalesceErrors(errs)
+	}
+	return append(changes, controllerChanges...), nil
 }
 
 func (exe localControlPlaneExecutor) GetName() string {
@@ -173,8 +187,23 @@ func NewExecutor(opt Options) (exe execute.Executor, err error) {
 	return newControlPlaneExecutor(controllerExecutors, opt.Namespace, opt.Name, &controlPlane), nil
 }
 
-func runExecutors(executors []execute.Executor) error {
-	if errs, _ := execute.ForParallel(executors); len(errs) > 0 {
+func planControlPlane(namespace, name string, controlPlane interface{}) ([]execute.Change, error) {
+	ns, err := config.GetNamespace(namespace)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ns.GetControlPlane(); err != nil {
+		return []execute.Change{{Name: name, Action: execute.CreateAction}}, nil
+	}
+	upToDate, err := diff.IsUpToDate(config.LocalControlPlaneKind, namespace, name, controlPlane)
+	if err != nil {
+		return nil, err
+	}
+	return []execute.Change{{Name: name, Action: execute.GetUpdateAction(upToDate)}}, nil
+}
+
+func runExecutors(ctx context.Context, executors []execute.Executor) error {
+	if errs, _ := execute.ForParallel(ctx, executors); len(errs) > 0 {
 		return execute.CoalesceErrors(errs)
 	}
 	return nil
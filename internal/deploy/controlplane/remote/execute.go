@@ -14,6 +14,7 @@
 package deployremotecontrolplane
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -22,6 +23,7 @@ import (
 	deployagent "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/agent"
 	deployagentconfig "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/agentconfig"
 	deployremotecontroller "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/controller/remote"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/diff"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	iutil "github.com/eclipse-iofog/iofogctl/v3/internal/util"
@@ -44,7 +46,7 @@ type remoteControlPlaneExecutor struct {
 	name                string
 }
 
-func deploySystemAgent(namespace string, ctrl *rsc.RemoteController, systemAgent rsc.Package) (err error) {
+func deploySystemAgent(ctx context.Context, namespace string, ctrl *rsc.RemoteController, systemAgent rsc.Package) (err error) {
 	// Deploy system agent to host internal router
 	install.Verbose("Deploying system agent")
 	agent := rsc.RemoteAgent{
@@ -72,7 +74,7 @@ func deploySystemAgent(namespace string, ctrl *rsc.RemoteController, systemAgent
 	// Get Agentconfig executor
 	deployAgentConfigExecutor := deployagentconfig.NewRemoteExecutor(iofog.VanillaRouterAgentName, &deployAgentConfig, namespace, nil)
 	// If there already is a system fog, ignore error
-	if err := deployAgentConfigExecutor.Execute(); err != nil {
+	if err := deployAgentConfigExecutor.Execute(ctx); err != nil {
 		return err
 	}
 	agent.UUID = deployAgentConfigExecutor.GetAgentUUID()
@@ -80,10 +82,10 @@ func deploySystemAgent(namespace string, ctrl *rsc.RemoteController, systemAgent
 	if err != nil {
 		return err
 	}
-	return agentDeployExecutor.Execute()
+	return agentDeployExecutor.Execute(ctx)
 }
 
-func (exe remoteControlPlaneExecutor) postDeploy() (err error) {
+func (exe remoteControlPlaneExecutor) postDeploy(ctx context.Context) (err error) {
 	// Look for a Vanilla controller
 	controllers := exe.controlPlane.GetControllers()
 	for _, baseController := range controllers {
@@ -95,16 +97,16 @@ func (exe remoteControlPlaneExecutor) postDeploy() (err error) {
 		if !ok {
 			return util.NewInternalError("Could not convert ControlPlane to Remote ControlPlane")
 		}
-		if err := deploySystemAgent(exe.ns.Name, controller, remoteControlPlane.SystemAgent); err != nil {
+		if err := deploySystemAgent(ctx, exe.ns.Name, controller, remoteControlPlane.SystemAgent); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (exe remoteControlPlaneExecutor) Execute() (err error) {
+func (exe remoteControlPlaneExecutor) Execute(ctx context.Context) (err error) {
 	util.SpinStart(fmt.Sprintf("Deploying controlplane %s", exe.GetName()))
-	if err := runExecutors(exe.controllerExecutors); err != nil {
+	if err := runExecutors(ctx, exe.controllerExecutors); err != nil {
 		return err
 	}
 
@@ -113,7 +115,7 @@ func (exe remoteControlPlaneExecutor) Execute() (err error) {
 	if err != nil {
 		return
 	}
-	if err := install.WaitForControllerAPI(endpoint); err != nil {
+	if err := install.WaitForControllerAPI(ctx, endpoint); err != nil {
 		return err
 	}
 	// Create new user
@@ -143,7 +145,19 @@ func (exe remoteControlPlaneExecutor) Execute() (err error) {
 		return err
 	}
 	// Post deploy steps
-	return exe.postDeploy()
+	return exe.postDeploy(ctx)
+}
+
+func (exe remoteControlPlaneExecutor) Plan() ([]execute.Change, error) {
+	changes, err := planControlPlane(exe.ns.Name, exe.name, exe.controlPlane)
+	if err != nil {
+		return nil, err
+	}
+	controllerChanges, errs := execute.PlanExecutors(exe.controllerExecutors, config.RemoteControllerKind)
+	if len(errs) > 0 {
+		return nil, execute.CoalesceErrors(errs)
+	}
+	return append(changes, controllerChanges...), nil
 }
 
 func (exe remoteControlPlaneExecutor) GetName() string {
@@ -190,8 +204,23 @@ func NewExecutor(opt Options) (exe execute.Executor, err error) {
 	return newControlPlaneExecutor(controllerExecutors, ns, opt.Name, &controlPlane), nil
 }
 
-func runExecutors(executors []execute.Executor) error {
-	if errs, _ := execute.ForParallel(executors); len(errs) > 0 {
+func planControlPlane(namespace, name string, controlPlane interface{}) ([]execute.Change, error) {
+	ns, err := config.GetNamespace(namespace)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := ns.GetControlPlane(); err != nil {
+		return []execute.Change{{Name: name, Action: execute.CreateAction}}, nil
+	}
+	upToDate, err := diff.IsUpToDate(config.RemoteControlPlaneKind, namespace, name, controlPlane)
+	if err != nil {
+		return nil, err
+	}
+	return []execute.Change{{Name: name, Action: execute.GetUpdateAction(upToDate)}}, nil
+}
+
+func runExecutors(ctx context.Context, executors []execute.Executor) error {
+	if errs, _ := execute.ForParallel(ctx, executors); len(errs) > 0 {
 		return execute.CoalesceErrors(errs)
 	}
 	return nil
@@ -14,6 +14,9 @@
 package deployroute
 
 import (
+	"context"
+	"reflect"
+
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
@@ -39,11 +42,7 @@ func (exe *executor) GetName() string {
 	return "deploying Edge Resource " + exe.name
 }
 
-func (exe *executor) Execute() (err error) {
-	if _, err = config.GetNamespace(exe.namespace); err != nil {
-		return
-	}
-
+func (exe *executor) getEdgeResource() *client.EdgeResourceMetadata {
 	// Translate edge resource to client type
 	edge := &client.EdgeResourceMetadata{
 		Name:              exe.name,
@@ -52,11 +51,49 @@ func (exe *executor) Execute() (err error) {
 		InterfaceProtocol: exe.edge.InterfaceProtocol,
 		Display:           exe.edge.Display,
 		OrchestrationTags: exe.edge.OrchestrationTags,
-		Interface: client.HTTPEdgeResource{
+		Custom:            exe.edge.Custom,
+	}
+	if exe.edge.Interface != nil {
+		edge.Interface = client.HTTPEdgeResource{
 			Endpoints: exe.edge.Interface.Endpoints,
-		},
-		Custom: exe.edge.Custom,
+		}
+	}
+	return edge
+}
+
+func (exe *executor) Plan() ([]execute.Change, error) {
+	name := exe.name + "/" + exe.edge.Version
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		if rsc.IsNoControlPlaneError(err) {
+			return []execute.Change{{Name: name, Action: execute.CreateAction}}, nil
+		}
+		return nil, err
 	}
+
+	current, err := clt.GetHTTPEdgeResourceByName(exe.name, exe.edge.Version)
+	if err != nil {
+		if util.IsNotFoundError(err) {
+			return []execute.Change{{Name: name, Action: execute.CreateAction}}, nil
+		}
+		return nil, err
+	}
+	edge := exe.getEdgeResource()
+	if current.Description == edge.Description &&
+		current.InterfaceProtocol == edge.InterfaceProtocol &&
+		reflect.DeepEqual(current.OrchestrationTags, edge.OrchestrationTags) &&
+		reflect.DeepEqual(current.Interface, edge.Interface) {
+		return []execute.Change{{Name: name, Action: execute.NoOpAction}}, nil
+	}
+	return []execute.Change{{Name: name, Action: execute.UpdateAction}}, nil
+}
+
+func (exe *executor) Execute(ctx context.Context) (err error) {
+	if _, err = config.GetNamespace(exe.namespace); err != nil {
+		return
+	}
+
+	edge := exe.getEdgeResource()
 	// Connect to Controller
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
@@ -14,7 +14,11 @@
 package deploy
 
 import (
+	"context"
 	"fmt"
+	"os"
+	"sort"
+	"time"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
@@ -34,13 +38,16 @@ import (
 	deployroute "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/route"
 	deployvolume "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/volume"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/plugin"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/wait"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 	"github.com/twmb/algoimpl/go/graph"
 )
 
+// kindOrder lists the kinds deployed as a dependency graph, ordered so that a kind only refers to the kinds before it
 var kindOrder = []config.Kind{
 	config.RemoteAgentKind,
 	config.LocalAgentKind,
@@ -56,7 +63,29 @@ var kindOrder = []config.Kind{
 
 type Options struct {
 	Namespace string
-	InputFile string
+	Input     execute.InputOptions
+	DryRun    bool
+	Prune     bool
+	Yes       bool
+	Atomic    bool
+	// ContinueOnError deploys every document which does not depend on a failed one
+	ContinueOnError bool
+	// FailedManifest is the file to write the documents which failed or were skipped to
+	FailedManifest string
+	// Parallelism limits the number of resources deployed at once, 0 is unlimited
+	Parallelism int
+	// KindParallelism overrides Parallelism for each kind
+	KindParallelism map[string]int
+	// Retries is the number of times a resource is deployed again after a transient failure
+	Retries int
+	// KindRetries overrides Retries for each kind
+	KindRetries map[string]int
+	// RetryBackoff is the delay before the first retry, doubled after each retry
+	RetryBackoff time.Duration
+	// Wait waits until the deployed microservices are RUNNING
+	Wait bool
+	// WaitTimeout bounds the wait for all Namespaces, 0 waits until the command is cancelled
+	WaitTimeout time.Duration
 }
 
 func deployEdgeResource(opt *execute.KindHandlerOpt) (exe execute.Executor, err error) {
@@ -140,17 +169,312 @@ var kindHandlers = map[config.Kind]func(*execute.KindHandlerOpt) (execute.Execut
 	config.RegistryKind:               deployRegistry,
 	config.VolumeKind:                 deployVolume,
 	config.RouteKind:                  deployRoute,
+	execute.OtherKinds:                plugin.NewKindHandler(plugin.DeployVerb),
 }
 
-// Execute deploy from yaml file
-func Execute(opt *Options) (err error) {
-	executorsMap, err := execute.GetExecutorsFromYAML(opt.InputFile, opt.Namespace, kindHandlers)
+// getPluginKinds returns the kinds of the executors which are deployed by plugins, sorted by name
+func getPluginKinds(executorsMap map[config.Kind][]execute.Executor) (kinds []config.Kind) {
+	for kind := range executorsMap {
+		if _, found := kindHandlers[kind]; !found {
+			kinds = append(kinds, kind)
+		}
+	}
+	sort.Slice(kinds, func(i, j int) bool {
+		return kinds[i] < kinds[j]
+	})
+	return kinds
+}
+
+// Execute deploy from yaml files
+func Execute(ctx context.Context, opt *Options) (err error) {
+	if opt.Atomic && opt.ContinueOnError {
+		return util.NewInputError("Cannot roll back changes with --atomic while continuing on errors with --continue-on-error")
+	}
+	if err := setExecutionPolicies(opt); err != nil {
+		return err
+	}
+	if opt.Prune && !opt.Yes && !opt.DryRun && opt.Input.ReadsStdin() {
+		return util.NewInputError("Cannot confirm the resources to prune while reading the YAML documents from stdin, use --yes")
+	}
+
+	documents := []*document{}
+	nsExecutors, err := execute.GetExecutorsPerNamespaceFromYAML(&opt.Input, opt.Namespace, recordDocuments(kindHandlers, &documents))
+	if err != nil {
+		return err
+	}
+	deployments, err := newNamespaceDeployments(nsExecutors, documents, opt.Prune)
 	if err != nil {
 		return err
 	}
 
-	// Create any AgentConfig executor missing
-	// Each Agent requires a corresponding Agent Config to be created with Controller
+	if opt.DryRun {
+		for _, dep := range deployments {
+			printNamespace(deployments, dep)
+			if err := plan(dep.executorsMap, dep.pruneTargets); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// Confirm deletions before deploying anything
+	if !opt.Yes {
+		for _, dep := range deployments {
+			if len(dep.pruneTargets) == 0 {
+				continue
+			}
+			printNamespace(deployments, dep)
+			confirmed, err := confirmPrune(dep.pruneTargets)
+			if err != nil {
+				return err
+			}
+			if !confirmed {
+				return util.NewInputError("Aborted deployment, resources to prune were not confirmed")
+			}
+		}
+	}
+
+	// Deploy one namespace after the other
+	errs := []error{}
+	for _, dep := range deployments {
+		depErr := deployAll(ctx, opt, dep)
+		// Changes are rolled back when an atomic deployment fails
+		if depErr == nil || !opt.Atomic {
+			recordRevisions(dep.namespace, dep.documents, dep.report.Results())
+		}
+		if depErr != nil {
+			errs = append(errs, depErr)
+			if !opt.ContinueOnError {
+				break
+			}
+		}
+	}
+	switch len(errs) {
+	case 0:
+	case 1:
+		err = errs[0]
+	default:
+		err = execute.CoalesceErrors(errs)
+	}
+
+	// Report the result of every executor
+	util.SpinStop()
+	failedDocuments := []*document{}
+	for _, dep := range deployments {
+		if len(dep.report.Results()) == 0 {
+			continue
+		}
+		fmt.Println()
+		printNamespace(deployments, dep)
+		if printErr := dep.report.Print(os.Stdout); printErr != nil {
+			return printErr
+		}
+		fmt.Println()
+		failedDocuments = append(failedDocuments, getFailedDocuments(dep.documents, dep.report.Unsuccessful())...)
+	}
+	if opt.FailedManifest != "" && len(failedDocuments) > 0 {
+		if writeErr := writeFailedManifest(opt.FailedManifest, failedDocuments); writeErr != nil {
+			return writeErr
+		}
+		util.PrintNotify(fmt.Sprintf("Wrote the documents which were not deployed to %s", opt.FailedManifest))
+	}
+	if err != nil || !opt.Wait {
+		return err
+	}
+	if opt.WaitTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opt.WaitTimeout)
+		defer cancel()
+	}
+	for _, dep := range deployments {
+		if err := waitForMicroservices(ctx, dep.namespace, dep.documents); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// waitForMicroservices waits until the microservices of the deployed Applications and Microservices are RUNNING
+func waitForMicroservices(ctx context.Context, namespace string, documents []*document) error {
+	applications := []string{}
+	microservices := []string{}
+	for _, doc := range documents {
+		switch doc.kind {
+		case config.ApplicationKind:
+			applications = append(applications, doc.name)
+		case config.MicroserviceKind:
+			microservices = append(microservices, doc.name)
+		}
+	}
+	return wait.Microservices(ctx, namespace, applications, microservices, 0)
+}
+
+// deployAll deploys the documents of a namespace
+func deployAll(ctx context.Context, opt *Options, dep *namespaceDeployment) (err error) {
+	executorsMap, documents, pruneTargets, report := dep.executorsMap, dep.documents, dep.pruneTargets, dep.report
+	errs := []error{}
+	// failed records errors and returns true if the deployment must stop
+	failed := func(phaseErrs []error) bool {
+		errs = append(errs, phaseErrs...)
+		return len(phaseErrs) > 0 && !opt.ContinueOnError
+	}
+
+	// ControlPlanes (should only be 1)
+	cpCount := 0
+	errMsg := "Specified multiple Control Planes in a single Namespace"
+	if exe, exists := executorsMap[config.KubernetesControlPlaneKind]; exists {
+		if failed(execute.RunReportedExecutors(ctx, exe, config.KubernetesControlPlaneKind, report)) {
+			return execute.CoalesceErrors(errs)
+		}
+		cpCount++
+	}
+	if exe, exists := executorsMap[config.RemoteControlPlaneKind]; exists {
+		if cpCount > 0 {
+			err = util.NewInputError(errMsg)
+		}
+		if failed(execute.RunReportedExecutors(ctx, exe, config.RemoteControlPlaneKind, report)) {
+			return execute.CoalesceErrors(errs)
+		}
+		cpCount++
+	}
+	if exe, exists := executorsMap[config.LocalControlPlaneKind]; exists {
+		if cpCount > 0 {
+			err = util.NewInputError(errMsg)
+		}
+		if failed(execute.RunReportedExecutors(ctx, exe, config.LocalControlPlaneKind, report)) {
+			return execute.CoalesceErrors(errs)
+		}
+	}
+
+	// Controllers
+	if failed(execute.RunReportedExecutors(ctx, executorsMap[config.LocalControllerKind], config.LocalControllerKind, report)) {
+		return execute.CoalesceErrors(errs)
+	}
+
+	if !opt.Atomic {
+		if err := deployResources(ctx, executorsMap, documents, pruneTargets, report, opt.ContinueOnError); err != nil {
+			errs = append(errs, err)
+		}
+	} else {
+		// Take snapshots of the resources to roll back on failure
+		snapshots, agentAttempts, err := takeSnapshots(dep.namespace, documents, executorsMap[config.AgentConfigKind], pruneTargets)
+		if err != nil {
+			return err
+		}
+		if err := deployResources(ctx, executorsMap, documents, pruneTargets, report, false); err != nil {
+			return rollback(dep.namespace, snapshots, agentAttempts, err)
+		}
+	}
+
+	if len(errs) > 0 {
+		return execute.CoalesceErrors(errs)
+	}
+	return nil
+}
+
+// deployResources deploys the Agent configurations and all documents following the Controllers, then prunes resources
+func deployResources(ctx context.Context, executorsMap map[config.Kind][]execute.Executor, documents []*document, pruneTargets []pruneTarget, report *execute.Report, continueOnError bool) error {
+	errs := []error{}
+
+	// Agent config
+	if err := deployAgentConfiguration(ctx, executorsMap[config.AgentConfigKind], report, continueOnError); err != nil {
+		if !continueOnError {
+			return err
+		}
+		errs = append(errs, err)
+	}
+
+	// Execute in parallel following the dependencies between documents
+	// Agents, Edge Resources, Application Templates, Volumes, Registries, CatalogItem, Application, Microservice, Route
+	deployGraph, err := newDeployGraph(documents, report)
+	if err != nil {
+		return err
+	}
+	if graphErrs := deployGraph.Execute(ctx); len(graphErrs) > 0 {
+		if !continueOnError {
+			return execute.CoalesceErrors(graphErrs)
+		}
+		errs = append(errs, graphErrs...)
+	}
+
+	// Documents handled by plugins can refer to any other document
+	for _, kind := range getPluginKinds(executorsMap) {
+		if kindErrs := execute.RunReportedExecutors(ctx, executorsMap[kind], kind, report); len(kindErrs) > 0 {
+			if !continueOnError {
+				return execute.CoalesceErrors(kindErrs)
+			}
+			errs = append(errs, kindErrs...)
+		}
+	}
+
+	// Delete resources which are no longer declared
+	if err := prune(ctx, pruneTargets, report); err != nil {
+		errs = append(errs, err)
+	}
+
+	if len(errs) > 0 {
+		return execute.CoalesceErrors(errs)
+	}
+	return nil
+}
+
+// setExecutionPolicies configures the parallelism and retries of the executors, globally and per kind
+func setExecutionPolicies(opt *Options) error {
+	if opt.Parallelism < 0 {
+		return util.NewInputError("Parallelism cannot be negative")
+	}
+	if opt.Retries < 0 {
+		return util.NewInputError("Retries cannot be negative")
+	}
+	if opt.RetryBackoff < 0 {
+		return util.NewInputError("Retry backoff cannot be negative")
+	}
+	newPolicy := func(retries int) execute.RetryPolicy {
+		policy := execute.GetRetryPolicy("")
+		policy.Attempts = retries + 1
+		if opt.RetryBackoff > 0 {
+			policy.Backoff = opt.RetryBackoff
+		}
+		return policy
+	}
+	execute.SetParallelism(opt.Parallelism)
+	execute.SetRetryPolicy(newPolicy(opt.Retries))
+
+	for kindName, limit := range opt.KindParallelism {
+		kind := config.Kind(kindName)
+		if !isKnownKind(kind) {
+			return util.NewInputError(fmt.Sprintf("Cannot set the parallelism of unknown kind %s", kindName))
+		}
+		if limit < 0 {
+			return util.NewInputError(fmt.Sprintf("Parallelism of %s cannot be negative", kindName))
+		}
+		execute.SetKindParallelism(kind, limit)
+	}
+	for kindName, retries := range opt.KindRetries {
+		kind := config.Kind(kindName)
+		if !isKnownKind(kind) {
+			return util.NewInputError(fmt.Sprintf("Cannot set the retries of unknown kind %s", kindName))
+		}
+		if retries < 0 {
+			return util.NewInputError(fmt.Sprintf("Retries of %s cannot be negative", kindName))
+		}
+		execute.SetKindRetryPolicy(kind, newPolicy(retries))
+	}
+	return nil
+}
+
+// isKnownKind returns true if the kind has a handler or a plugin
+func isKnownKind(kind config.Kind) bool {
+	if _, exists := kindHandlers[kind]; exists {
+		return kind != execute.OtherKinds
+	}
+	_, err := plugin.GetKindPath(kind)
+	return err == nil
+}
+
+// addAgentConfigExecutors creates any AgentConfig executor missing.
+// Each Agent requires a corresponding Agent Config to be created with Controller
+func addAgentConfigExecutors(executorsMap map[config.Kind][]execute.Executor, namespace string) error {
 	appendedAgentExecs := append(executorsMap[config.LocalAgentKind], executorsMap[config.RemoteAgentKind]...)
 	for _, agentGenericExecutor := range appendedAgentExecs {
 		agentExecutor, ok := agentGenericExecutor.(deployagent.AgentDeployExecutor)
@@ -194,61 +518,15 @@ func Execute(opt *Options) (err error) {
 					Name:               agentExecutor.GetName(),
 					AgentConfiguration: agentConfig,
 				},
-				opt.Namespace,
+				namespace,
 				tags,
 			))
 		}
 	}
-
-	// ControlPlanes (should only be 1)
-	cpCount := 0
-	errMsg := "Specified multiple Control Planes in a single Namespace"
-	if exe, exists := executorsMap[config.KubernetesControlPlaneKind]; exists {
-		if errs := execute.RunExecutors(exe, "deploy Kubernetes Control Plane"); len(errs) > 0 {
-			return execute.CoalesceErrors(errs)
-		}
-		cpCount++
-	}
-	if exe, exists := executorsMap[config.RemoteControlPlaneKind]; exists {
-		if cpCount > 0 {
-			err = util.NewInputError(errMsg)
-		}
-		if errs := execute.RunExecutors(exe, "deploy Remote Control Plane"); len(errs) > 0 {
-			return execute.CoalesceErrors(errs)
-		}
-		cpCount++
-	}
-	if exe, exists := executorsMap[config.LocalControlPlaneKind]; exists {
-		if cpCount > 0 {
-			err = util.NewInputError(errMsg)
-		}
-		if errs := execute.RunExecutors(exe, "deploy Local Control Plane"); len(errs) > 0 {
-			return execute.CoalesceErrors(errs)
-		}
-	}
-
-	// Controllers
-	if errs := execute.RunExecutors(executorsMap[config.LocalControllerKind], "deploy local controller"); len(errs) > 0 {
-		return execute.CoalesceErrors(errs)
-	}
-
-	// Agent config
-	if err := deployAgentConfiguration(executorsMap[config.AgentConfigKind]); err != nil {
-		return err
-	}
-
-	// Execute in parallel by priority order
-	// Edge Resources, Agents, Volumes, CatalogItem, Application, Microservice, Route
-	for idx := range kindOrder {
-		if errs := execute.RunExecutors(executorsMap[kindOrder[idx]], fmt.Sprintf("deploy %s", kindOrder[idx])); len(errs) > 0 {
-			return execute.CoalesceErrors(errs)
-		}
-	}
-
 	return nil
 }
 
-func deployAgentConfiguration(executors []execute.Executor) (err error) {
+func deployAgentConfiguration(ctx context.Context, executors []execute.Executor, report *execute.Report, continueOnError bool) (err error) {
 	if len(executors) == 0 {
 		return nil
 	}
@@ -266,7 +544,7 @@ func deployAgentConfiguration(executors []execute.Executor) (err error) {
 	}
 
 	for namespace, executors := range executorsByNamespace {
-		if err := sortAndExecute(namespace, executors); err != nil {
+		if err := sortAndExecute(ctx, namespace, executors, report, continueOnError); err != nil {
 			return err
 		}
 	}
@@ -274,7 +552,7 @@ func deployAgentConfiguration(executors []execute.Executor) (err error) {
 	return nil
 }
 
-func sortAndExecute(namespace string, executors []deployagentconfig.AgentConfigExecutor) error {
+func sortAndExecute(ctx context.Context, namespace string, executors []deployagentconfig.AgentConfigExecutor, report *execute.Report, continueOnError bool) error {
 	// List agents on Controller
 	ctrlClient, err := clientutil.NewControllerClient(namespace)
 	if err != nil {
@@ -339,14 +617,30 @@ func sortAndExecute(namespace string, executors []deployagentconfig.AgentConfigE
 
 	// Sort and execute
 	sortedExecutors := g.TopologicalSort()
+	errs := []error{}
 	for i := range sortedExecutors {
 		executor, ok := (*sortedExecutors[i].Value).(execute.Executor)
 		if !ok {
 			return util.NewInternalError("Failed to convert node to executor")
 		}
-		if err := executor.Execute(); err != nil {
-			return err
+		if ctx.Err() != nil {
+			errs = append(errs, execute.NewCancelledError(ctx, executor.GetName()))
+			break
 		}
+		// Agents which are already on Controller are not reported
+		if _, isAgentConfig := executor.(deployagentconfig.AgentConfigExecutor); isAgentConfig {
+			executor = execute.NewRetryExecutor(executor, config.AgentConfigKind, execute.GetRetryPolicy(config.AgentConfigKind))
+			executor = execute.NewReportingExecutor(executor, config.AgentConfigKind, report)
+		}
+		if err := executor.Execute(ctx); err != nil {
+			if !continueOnError {
+				return err
+			}
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return execute.CoalesceErrors(errs)
 	}
 	return nil
 }
@@ -0,0 +1,231 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deploy
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/apps"
+	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
+	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// document is a YAML document of the input file along with the executor deploying it
+type document struct {
+	kind      config.Kind
+	namespace string
+	name      string
+	tags      *[]string
+	yaml      []byte
+	exe       execute.Executor
+}
+
+// recordDocuments returns kind handlers which record each document along with its executor
+func recordDocuments(handlers map[config.Kind]func(*execute.KindHandlerOpt) (execute.Executor, error), documents *[]*document) map[config.Kind]func(*execute.KindHandlerOpt) (execute.Executor, error) {
+	recordingHandlers := make(map[config.Kind]func(*execute.KindHandlerOpt) (execute.Executor, error))
+	for kind, handler := range handlers {
+		handler := handler
+		recordingHandlers[kind] = func(opt *execute.KindHandlerOpt) (execute.Executor, error) {
+			exe, err := handler(opt)
+			if err != nil || exe == nil {
+				return exe, err
+			}
+			*documents = append(*documents, &document{
+				kind:      opt.Kind,
+				namespace: opt.Namespace,
+				name:      opt.Name,
+				tags:      opt.Tags,
+				yaml:      opt.YAML,
+				exe:       exe,
+			})
+			return exe, nil
+		}
+	}
+	return recordingHandlers
+}
+
+// resourceKey identifies a resource which documents can depend on.
+// An empty name stands for any resource of the kind
+type resourceKey struct {
+	kind config.Kind
+	name string
+}
+
+// newDeployGraph creates a graph of the documents of the kinds in kindOrder.
+// Each document depends on the documents deploying the resources it refers to
+func newDeployGraph(documents []*document, report *execute.Report) (*execute.Graph, error) {
+	isGraphKind := make(map[config.Kind]bool)
+	for _, kind := range kindOrder {
+		isGraphKind[kind] = true
+	}
+
+	g := execute.NewGraph(report)
+	nodes := make(map[*document]*execute.GraphNode)
+	providers := make(map[resourceKey][]*execute.GraphNode)
+	for _, doc := range documents {
+		if !isGraphKind[doc.kind] {
+			continue
+		}
+		nodes[doc] = g.AddNode(doc.kind, doc.name, doc.exe)
+		provided, err := getProvidedResources(doc)
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range provided {
+			providers[key] = append(providers[key], nodes[doc])
+		}
+	}
+
+	for _, doc := range documents {
+		node, found := nodes[doc]
+		if !found {
+			continue
+		}
+		dependencies, err := getDocumentDependencies(doc)
+		if err != nil {
+			return nil, err
+		}
+		// Resources which are not declared in the file must already be deployed
+		for _, key := range dependencies {
+			for _, provider := range providers[key] {
+				g.AddDependency(node, provider)
+			}
+		}
+	}
+	return g, nil
+}
+
+// getProvidedResources returns the resources deployed by a document
+func getProvidedResources(doc *document) ([]resourceKey, error) {
+	provided := []resourceKey{
+		{kind: doc.kind, name: doc.name},
+		{kind: doc.kind},
+	}
+	switch doc.kind {
+	case config.ApplicationKind:
+		var application apps.Application
+		if err := yaml.Unmarshal(doc.yaml, &application); err != nil {
+			return nil, util.NewUnmarshalError(err.Error())
+		}
+		for _, msvc := range application.Microservices {
+			provided = append(provided, resourceKey{kind: config.MicroserviceKind, name: fmt.Sprintf("%s/%s", doc.name, msvc.Name)})
+		}
+		for _, route := range application.Routes {
+			provided = append(provided, resourceKey{kind: config.RouteKind, name: fmt.Sprintf("%s/%s", doc.name, route.Name)})
+		}
+	}
+	return provided, nil
+}
+
+// getDocumentDependencies returns the resources a document refers to
+func getDocumentDependencies(doc *document) (dependencies []resourceKey, err error) {
+	switch doc.kind {
+	case config.ApplicationKind:
+		var application apps.Application
+		if err = yaml.Unmarshal(doc.yaml, &application); err != nil {
+			return nil, util.NewUnmarshalError(err.Error())
+		}
+		for idx := range application.Microservices {
+			dependencies = append(dependencies, getMicroserviceDependencies(&application.Microservices[idx])...)
+		}
+		if application.Template != nil && application.Template.Name != "" {
+			dependencies = append(dependencies, resourceKey{kind: config.ApplicationTemplateKind, name: application.Template.Name})
+		}
+	case config.MicroserviceKind:
+		var msvc apps.Microservice
+		if err = yaml.Unmarshal(doc.yaml, &msvc); err != nil {
+			return nil, util.NewUnmarshalError(err.Error())
+		}
+		dependencies = getMicroserviceDependencies(&msvc)
+		appName, _, err := clientutil.ParseFQName(doc.name, "Microservice")
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies, resourceKey{kind: config.ApplicationKind, name: appName})
+	case config.RouteKind:
+		var route rsc.Route
+		if err = yaml.Unmarshal(doc.yaml, &route); err != nil {
+			return nil, util.NewUnmarshalError(err.Error())
+		}
+		appName, _, err := clientutil.ParseFQName(doc.name, "Route")
+		if err != nil {
+			return nil, err
+		}
+		dependencies = append(dependencies,
+			resourceKey{kind: config.ApplicationKind, name: appName},
+			resourceKey{kind: config.MicroserviceKind, name: fmt.Sprintf("%s/%s", appName, route.From)},
+			resourceKey{kind: config.MicroserviceKind, name: fmt.Sprintf("%s/%s", appName, route.To)},
+		)
+	case config.VolumeKind:
+		var volume rsc.Volume
+		if err = yaml.Unmarshal(doc.yaml, &volume); err != nil {
+			return nil, util.NewUnmarshalError(err.Error())
+		}
+		for _, agent := range volume.Agents {
+			dependencies = append(dependencies, getAgentDependencies(agent)...)
+		}
+	case config.CatalogItemKind:
+		var catalogItem apps.CatalogItem
+		if err = yaml.Unmarshal(doc.yaml, &catalogItem); err != nil {
+			return nil, util.NewUnmarshalError(err.Error())
+		}
+		dependencies = getRegistryDependencies(catalogItem.Registry)
+	}
+	return dependencies, nil
+}
+
+func getMicroserviceDependencies(msvc *apps.Microservice) (dependencies []resourceKey) {
+	if msvc.Agent.Name != "" {
+		dependencies = append(dependencies, getAgentDependencies(msvc.Agent.Name)...)
+	}
+	if msvc.Images != nil {
+		// Catalog Item IDs are only known once deployed
+		if msvc.Images.CatalogID != 0 {
+			dependencies = append(dependencies, resourceKey{kind: config.CatalogItemKind})
+		}
+		dependencies = append(dependencies, getRegistryDependencies(msvc.Images.Registry)...)
+	}
+	return
+}
+
+func getAgentDependencies(name string) []resourceKey {
+	return []resourceKey{
+		{kind: config.RemoteAgentKind, name: name},
+		{kind: config.LocalAgentKind, name: name},
+	}
+}
+
+func getRegistryDependencies(registry string) []resourceKey {
+	if registry == "" {
+		return nil
+	}
+	if _, builtIn := client.RegistryTypeRegistryTypeIDDict[registry]; builtIn {
+		return nil
+	}
+	id, err := strconv.Atoi(registry)
+	if err != nil {
+		return nil
+	}
+	if _, builtIn := client.RegistryTypeIDRegistryTypeDict[id]; builtIn {
+		return nil
+	}
+	// Registry IDs are only known once deployed
+	return []resourceKey{{kind: config.RegistryKind}}
+}
@@ -0,0 +1,94 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+)
+
+func TestGetDocumentDependencies(t *testing.T) {
+	testCases := []struct {
+		doc      *document
+		expected []resourceKey
+	}{
+		{
+			doc: &document{
+				kind: config.MicroserviceKind,
+				name: "app/msvc",
+				yaml: []byte("agent:\n  name: agent-1\nimages:\n  x86: nginx\n  registry: remote\n"),
+			},
+			expected: []resourceKey{
+				{kind: config.RemoteAgentKind, name: "agent-1"},
+				{kind: config.LocalAgentKind, name: "agent-1"},
+				{kind: config.ApplicationKind, name: "app"},
+			},
+		},
+		{
+			doc: &document{
+				kind: config.RouteKind,
+				name: "app/route",
+				yaml: []byte("from: a\nto: b\n"),
+			},
+			expected: []resourceKey{
+				{kind: config.ApplicationKind, name: "app"},
+				{kind: config.MicroserviceKind, name: "app/a"},
+				{kind: config.MicroserviceKind, name: "app/b"},
+			},
+		},
+		{
+			doc: &document{
+				kind: config.ApplicationKind,
+				name: "app",
+				yaml: []byte("microservices:\n- name: msvc\n  agent:\n    name: agent-1\n  images:\n    catalogId: 100\n    registry: \"3\"\ntemplate:\n  name: tmpl\n"),
+			},
+			expected: []resourceKey{
+				{kind: config.RemoteAgentKind, name: "agent-1"},
+				{kind: config.LocalAgentKind, name: "agent-1"},
+				{kind: config.CatalogItemKind},
+				{kind: config.RegistryKind},
+				{kind: config.ApplicationTemplateKind, name: "tmpl"},
+			},
+		},
+		{
+			doc: &document{
+				kind: config.VolumeKind,
+				name: "vol",
+				yaml: []byte("agents:\n- agent-2\n"),
+			},
+			expected: []resourceKey{
+				{kind: config.RemoteAgentKind, name: "agent-2"},
+				{kind: config.LocalAgentKind, name: "agent-2"},
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		dependencies, err := getDocumentDependencies(testCase.doc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(dependencies) != len(testCase.expected) {
+			t.Errorf("%s %s: expected %v, got %v", testCase.doc.kind, testCase.doc.name, testCase.expected, dependencies)
+			continue
+		}
+		for idx := range dependencies {
+			if dependencies[idx] != testCase.expected[idx] {
+				t.Errorf("%s %s: expected %v, got %v", testCase.doc.kind, testCase.doc.name, testCase.expected, dependencies)
+				break
+			}
+		}
+	}
+}
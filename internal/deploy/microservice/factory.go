@@ -14,11 +14,14 @@
 package deploymicroservice
 
 import (
+	"context"
 	"fmt"
 
 	apps "github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/apps"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/diff"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 	"gopkg.in/yaml.v2"
@@ -40,7 +43,7 @@ func (exe *remoteExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *remoteExecutor) Execute() error {
+func (exe *remoteExecutor) Execute(ctx context.Context) error {
 	util.SpinStart(fmt.Sprintf("Deploying microservice %s", exe.GetName()))
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
@@ -80,6 +83,32 @@ func (exe *remoteExecutor) Execute() error {
 	return apps.DeployMicroservice(controller, exe.microservice, appName, msvcName)
 }
 
+func (exe *remoteExecutor) Plan() ([]execute.Change, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		if rsc.IsNoControlPlaneError(err) {
+			return []execute.Change{{Name: exe.name, Action: execute.CreateAction}}, nil
+		}
+		return nil, err
+	}
+
+	appName, msvcName, err := clientutil.ParseFQName(exe.name, "Microservice")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := clt.GetMicroserviceByName(appName, msvcName); err != nil {
+		if util.IsNotFoundError(err) {
+			return []execute.Change{{Name: exe.name, Action: execute.CreateAction}}, nil
+		}
+		return nil, err
+	}
+	upToDate, err := diff.IsUpToDate(config.MicroserviceKind, exe.namespace, exe.name, exe.microservice)
+	if err != nil {
+		return nil, err
+	}
+	return []execute.Change{{Name: exe.name, Action: execute.GetUpdateAction(upToDate)}}, nil
+}
+
 func NewExecutor(opt Options) (exe execute.Executor, err error) {
 	// Check the namespace exists
 	if _, err = config.GetNamespace(opt.Namespace); err != nil {
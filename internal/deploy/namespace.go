@@ -0,0 +1,76 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deploy
+
+import (
+	"fmt"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+)
+
+// namespaceDeployment holds the documents of a namespace and the results of their deployment
+type namespaceDeployment struct {
+	namespace    string
+	executorsMap map[config.Kind][]execute.Executor
+	documents    []*document
+	pruneTargets []pruneTarget
+	report       *execute.Report
+}
+
+// newNamespaceDeployments groups the documents by namespace, in the order of the executors of each namespace.
+// The resources of each namespace which are no longer declared are found if prune is set
+func newNamespaceDeployments(nsExecutors []execute.NamespaceExecutors, documents []*document, prune bool) ([]*namespaceDeployment, error) {
+	deployments := []*namespaceDeployment{}
+	for _, nsExecutor := range nsExecutors {
+		dep := &namespaceDeployment{
+			namespace:    nsExecutor.Namespace,
+			executorsMap: nsExecutor.Executors,
+			report:       execute.NewReport(),
+		}
+		for _, doc := range documents {
+			if doc.namespace == dep.namespace {
+				dep.documents = append(dep.documents, doc)
+			}
+		}
+
+		// Create any AgentConfig executor missing
+		if err := addAgentConfigExecutors(dep.executorsMap, dep.namespace); err != nil {
+			return nil, err
+		}
+
+		// Find the resources which are no longer declared
+		if prune {
+			declared := make(declaredResources)
+			for _, doc := range dep.documents {
+				if err := declared.addDocument(doc); err != nil {
+					return nil, err
+				}
+			}
+			var err error
+			if dep.pruneTargets, err = getPruneTargets(dep.namespace, declared); err != nil {
+				return nil, err
+			}
+		}
+		deployments = append(deployments, dep)
+	}
+	return deployments, nil
+}
+
+// printNamespace prints the namespace of the deployment when several namespaces are deployed
+func printNamespace(deployments []*namespaceDeployment, dep *namespaceDeployment) {
+	if len(deployments) > 1 {
+		fmt.Printf("Namespace %s\n", dep.namespace)
+	}
+}
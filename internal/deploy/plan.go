@@ -0,0 +1,72 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deploy
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+)
+
+// planOrder is the order in which Execute deploys each kind
+var planOrder = append([]config.Kind{
+	config.KubernetesControlPlaneKind,
+	config.RemoteControlPlaneKind,
+	config.LocalControlPlaneKind,
+	config.LocalControllerKind,
+	config.AgentConfigKind,
+}, kindOrder...)
+
+// plan prints the changes the executors would apply without applying them
+func plan(executorsMap map[config.Kind][]execute.Executor, pruneTargets []pruneTarget) error {
+	changes := []execute.Change{}
+	// Documents handled by plugins are deployed last
+	kinds := append(append([]config.Kind{}, planOrder...), getPluginKinds(executorsMap)...)
+	for _, kind := range kinds {
+		kindChanges, errs := execute.PlanExecutors(executorsMap[kind], kind)
+		if len(errs) > 0 {
+			return execute.CoalesceErrors(errs)
+		}
+		changes = append(changes, kindChanges...)
+	}
+	changes = append(changes, getPruneChanges(pruneTargets)...)
+	return printPlan(changes)
+}
+
+func printPlan(changes []execute.Change) error {
+	writer := tabwriter.NewWriter(os.Stdout, 16, 8, 1, '\t', 0)
+	if _, err := fmt.Fprintf(writer, "KIND\tNAME\tACTION\tDETAILS\t\n"); err != nil {
+		return err
+	}
+	count := make(map[execute.Action]int)
+	for _, change := range changes {
+		count[change.Action]++
+		if _, err := fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t\n", change.Kind, change.Name, change.Action, change.Detail); err != nil {
+			return err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\nPlan: %d to create, %d to update, %d unchanged, %d to delete\n",
+		count[execute.CreateAction],
+		count[execute.UpdateAction],
+		count[execute.NoOpAction],
+		count[execute.DeleteAction],
+	)
+	return nil
+}
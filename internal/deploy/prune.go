@@ -0,0 +1,313 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/apps"
+	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	deleteapplication "github.com/eclipse-iofog/iofogctl/v3/internal/delete/application"
+	deleteedgeresource "github.com/eclipse-iofog/iofogctl/v3/internal/delete/edgeresource"
+	deleteregistry "github.com/eclipse-iofog/iofogctl/v3/internal/delete/registry"
+	deleteroute "github.com/eclipse-iofog/iofogctl/v3/internal/delete/route"
+	deletevolume "github.com/eclipse-iofog/iofogctl/v3/internal/delete/volume"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
+	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// prunableKinds are the kinds deleted by --prune when they are no longer declared
+var prunableKinds = map[config.Kind]bool{
+	config.EdgeResourceKind: true,
+	config.VolumeKind:       true,
+	config.RegistryKind:     true,
+	config.ApplicationKind:  true,
+	config.RouteKind:        true,
+}
+
+// pruneTarget is a live resource which is no longer declared
+type pruneTarget struct {
+	kind config.Kind
+	name string
+	exe  execute.Executor
+	// docName and docSpec are the name and spec of a document declaring the resource, used to describe it
+	docName string
+	docSpec map[string]interface{}
+}
+
+// declaredResources holds the names of the resources declared in the input file, per kind
+type declaredResources map[config.Kind]map[string]bool
+
+func (declared declaredResources) add(kind config.Kind, name string) {
+	if _, exists := declared[kind]; !exists {
+		declared[kind] = make(map[string]bool)
+	}
+	declared[kind][name] = true
+}
+
+func (declared declaredResources) has(kind config.Kind, name string) bool {
+	return declared[kind][name]
+}
+
+func (declared declaredResources) addDocument(doc *document) error {
+	switch doc.kind {
+	case config.ApplicationKind:
+		declared.add(doc.kind, doc.name)
+		// Routes can be declared within the Application
+		var application apps.Application
+		if err := yaml.Unmarshal(doc.yaml, &application); err != nil {
+			return util.NewUnmarshalError(err.Error())
+		}
+		for _, route := range application.Routes {
+			declared.add(config.RouteKind, fmt.Sprintf("%s/%s", doc.name, route.Name))
+		}
+	case config.RouteKind:
+		declared.add(doc.kind, doc.name)
+		fallthrough
+	case config.MicroserviceKind:
+		// Routes and Microservices declared on their own declare their Application, which must not be pruned
+		appName, _, err := clientutil.ParseFQName(doc.name, string(doc.kind))
+		if err != nil {
+			return err
+		}
+		if appName != "" {
+			declared.add(config.ApplicationKind, appName)
+		}
+	case config.VolumeKind:
+		declared.add(doc.kind, doc.name)
+	case config.EdgeResourceKind:
+		var edge rsc.EdgeResource
+		if err := yaml.Unmarshal(doc.yaml, &edge); err != nil {
+			return util.NewUnmarshalError(err.Error())
+		}
+		declared.add(doc.kind, fmt.Sprintf("%s/%s", doc.name, edge.Version))
+	case config.RegistryKind:
+		// Registries are identified by URL, their ID is only known after creation
+		var registry rsc.Registry
+		if err := yaml.Unmarshal(doc.yaml, &registry); err != nil {
+			return util.NewUnmarshalError(err.Error())
+		}
+		if registry.URL != nil {
+			declared.add(doc.kind, *registry.URL)
+		}
+		if registry.ID != 0 {
+			declared.add(doc.kind, strconv.Itoa(registry.ID))
+		}
+	}
+	return nil
+}
+
+// getPruneTargets returns the live resources of the Namespace which are not declared, in the reverse order of kindOrder
+func getPruneTargets(namespace string, declared declaredResources) (targets []pruneTarget, err error) {
+	ns, err := config.GetNamespace(namespace)
+	if err != nil {
+		return
+	}
+	targetsByKind := make(map[config.Kind][]pruneTarget)
+
+	// Volumes are only known to iofogctl
+	for _, volume := range ns.GetVolumes() {
+		if declared.has(config.VolumeKind, volume.Name) {
+			continue
+		}
+		exe, err := deletevolume.NewExecutor(namespace, volume.Name)
+		if err != nil {
+			return nil, err
+		}
+		targetsByKind[config.VolumeKind] = append(targetsByKind[config.VolumeKind], pruneTarget{
+			kind:    config.VolumeKind,
+			name:    volume.Name,
+			exe:     exe,
+			docName: volume.Name,
+		})
+	}
+
+	clt, err := clientutil.NewControllerClient(namespace)
+	if err != nil {
+		// Nothing else can be deployed without a Control Plane
+		if rsc.IsNoControlPlaneError(err) {
+			return getOrderedPruneTargets(targetsByKind), nil
+		}
+		return
+	}
+
+	edgeResources, err := clt.ListEdgeResources()
+	if err != nil {
+		return
+	}
+	for _, edge := range edgeResources.EdgeResources {
+		name := fmt.Sprintf("%s/%s", edge.Name, edge.Version)
+		if declared.has(config.EdgeResourceKind, name) {
+			continue
+		}
+		exe := deleteedgeresource.NewExecutor(namespace, edge.Name, edge.Version)
+		targetsByKind[config.EdgeResourceKind] = append(targetsByKind[config.EdgeResourceKind], pruneTarget{
+			kind:    config.EdgeResourceKind,
+			name:    name,
+			exe:     exe,
+			docName: edge.Name,
+			docSpec: map[string]interface{}{"version": edge.Version},
+		})
+	}
+
+	registries, err := clt.ListRegistries()
+	if err != nil {
+		return
+	}
+	for _, registry := range registries.Registries {
+		// Skip the built-in registries of the Controller
+		if _, builtIn := client.RegistryTypeIDRegistryTypeDict[registry.ID]; builtIn {
+			continue
+		}
+		name := strconv.Itoa(registry.ID)
+		if declared.has(config.RegistryKind, registry.URL) || declared.has(config.RegistryKind, name) {
+			continue
+		}
+		exe, err := deleteregistry.NewExecutor(namespace, name)
+		if err != nil {
+			return nil, err
+		}
+		targetsByKind[config.RegistryKind] = append(targetsByKind[config.RegistryKind], pruneTarget{
+			kind:    config.RegistryKind,
+			name:    registry.URL,
+			exe:     exe,
+			docSpec: map[string]interface{}{"id": registry.ID, "url": registry.URL},
+		})
+	}
+
+	applications, err := clt.GetAllApplications()
+	if err != nil {
+		return
+	}
+	prunedApplications := make(map[string]bool)
+	for _, application := range applications.Applications {
+		if application.IsSystem || declared.has(config.ApplicationKind, application.Name) {
+			continue
+		}
+		exe, err := deleteapplication.NewExecutor(namespace, application.Name)
+		if err != nil {
+			return nil, err
+		}
+		prunedApplications[application.Name] = true
+		targetsByKind[config.ApplicationKind] = append(targetsByKind[config.ApplicationKind], pruneTarget{
+			kind:    config.ApplicationKind,
+			name:    application.Name,
+			exe:     exe,
+			docName: application.Name,
+		})
+	}
+
+	routes, err := clt.ListRoutes()
+	if err != nil {
+		return
+	}
+	for _, route := range routes.Routes {
+		name := fmt.Sprintf("%s/%s", route.Application, route.Name)
+		// Routes are deleted along with their Application
+		if prunedApplications[route.Application] || declared.has(config.RouteKind, name) {
+			continue
+		}
+		exe := deleteroute.NewExecutor(namespace, name)
+		targetsByKind[config.RouteKind] = append(targetsByKind[config.RouteKind], pruneTarget{
+			kind:    config.RouteKind,
+			name:    name,
+			exe:     exe,
+			docName: name,
+		})
+	}
+
+	return getOrderedPruneTargets(targetsByKind), nil
+}
+
+func getOrderedPruneTargets(targetsByKind map[config.Kind][]pruneTarget) (targets []pruneTarget) {
+	for idx := len(kindOrder) - 1; idx >= 0; idx-- {
+		targets = append(targets, targetsByKind[kindOrder[idx]]...)
+	}
+	return
+}
+
+// getPruneChanges returns the deletions of the prune targets for the dry-run plan
+func getPruneChanges(targets []pruneTarget) (changes []execute.Change) {
+	for _, target := range targets {
+		changes = append(changes, execute.Change{
+			Kind:   target.kind,
+			Name:   target.name,
+			Action: execute.DeleteAction,
+			Detail: "No longer declared",
+		})
+	}
+	return
+}
+
+// confirmPrune prints the prune targets and asks the user to confirm their deletion
+func confirmPrune(targets []pruneTarget) (bool, error) {
+	util.SpinStop()
+	writer := tabwriter.NewWriter(os.Stdout, 16, 8, 1, '\t', 0)
+	if _, err := fmt.Fprintf(writer, "KIND\tNAME\t\n"); err != nil {
+		return false, err
+	}
+	for _, target := range targets {
+		if _, err := fmt.Fprintf(writer, "%s\t%s\t\n", target.kind, target.name); err != nil {
+			return false, err
+		}
+	}
+	if err := writer.Flush(); err != nil {
+		return false, err
+	}
+	return util.Confirm(fmt.Sprintf("\n%d resource(s) are no longer declared and will be deleted. Continue?", len(targets)))
+}
+
+// pruneExecutor deletes a prune target, ignoring targets which are already deleted
+type pruneExecutor struct {
+	target pruneTarget
+}
+
+func (exe *pruneExecutor) GetName() string {
+	return fmt.Sprintf("%s (pruned)", exe.target.name)
+}
+
+func (exe *pruneExecutor) Execute(ctx context.Context) error {
+	if err := exe.target.exe.Execute(ctx); err != nil {
+		if !util.IsNotFoundError(err) {
+			return err
+		}
+		util.PrintNotify(fmt.Sprintf("Warning: %s %s.", exe.target.kind, err.Error()))
+	}
+	return nil
+}
+
+// prune deletes the targets in order, running the deletions of each kind in parallel
+func prune(ctx context.Context, targets []pruneTarget, report *execute.Report) error {
+	for idx := len(kindOrder) - 1; idx >= 0; idx-- {
+		kind := kindOrder[idx]
+		exes := []execute.Executor{}
+		for _, target := range targets {
+			if target.kind == kind {
+				exes = append(exes, &pruneExecutor{target: target})
+			}
+		}
+		if errs := execute.RunReportedExecutors(ctx, exes, kind, report); len(errs) > 0 {
+			return execute.CoalesceErrors(errs)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deploy
+
+import (
+	"testing"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+)
+
+func TestDeclaredResources(t *testing.T) {
+	declared := make(declaredResources)
+	docs := []*document{
+		{kind: config.ApplicationKind, name: "app", yaml: []byte("routes:\n- name: r1\n  from: a\n  to: b\n")},
+		{kind: config.EdgeResourceKind, name: "edge", yaml: []byte("version: 1.0.0\n")},
+		{kind: config.RegistryKind, yaml: []byte("url: registry.example.com\n")},
+		{kind: config.MicroserviceKind, name: "msvc-app/msvc"},
+		{kind: config.RouteKind, name: "route-app/route"},
+	}
+	for _, doc := range docs {
+		if err := declared.addDocument(doc); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := map[config.Kind]string{
+		config.ApplicationKind:  "app",
+		config.RouteKind:        "app/r1",
+		config.EdgeResourceKind: "edge/1.0.0",
+		config.RegistryKind:     "registry.example.com",
+	}
+	for kind, name := range expected {
+		if !declared.has(kind, name) {
+			t.Errorf("Expected %s %s to be declared", kind, name)
+		}
+	}
+
+	// Standalone Microservices and Routes declare their Application
+	for _, name := range []string{"msvc-app", "route-app"} {
+		if !declared.has(config.ApplicationKind, name) {
+			t.Errorf("Expected Application %s to be declared", name)
+		}
+	}
+	if !declared.has(config.RouteKind, "route-app/route") {
+		t.Error("Expected Route route-app/route to be declared")
+	}
+	if declared.has(config.ApplicationKind, "other") {
+		t.Error("Expected Application other not to be declared")
+	}
+}
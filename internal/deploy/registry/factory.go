@@ -14,6 +14,7 @@
 package deployregistry
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
@@ -43,8 +44,12 @@ func (exe *remoteExecutor) GetName() string {
 	return ""
 }
 
-func (exe *remoteExecutor) Execute() error {
+func (exe *remoteExecutor) Execute(ctx context.Context) error {
 	util.SpinStart(fmt.Sprintf("Deploying registry %s", exe.GetName()))
+	// Keep the password out of the audit log
+	if exe.registry.Password != nil {
+		util.RedactAudit(*exe.registry.Password)
+	}
 	// Init remote resources
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
@@ -98,6 +103,69 @@ func (exe *remoteExecutor) Execute() error {
 	return nil
 }
 
+func (exe *remoteExecutor) Plan() ([]execute.Change, error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		if rsc.IsNoControlPlaneError(err) {
+			return []execute.Change{{Name: exe.GetName(), Action: execute.CreateAction}}, nil
+		}
+		return nil, err
+	}
+
+	registries, err := clt.ListRegistries()
+	if err != nil {
+		return nil, err
+	}
+
+	if exe.registry.ID == 0 {
+		change := execute.Change{Name: exe.GetName(), Action: execute.CreateAction}
+		for idx := range registries.Registries {
+			if registries.Registries[idx].URL == exe.GetName() {
+				change.Detail = fmt.Sprintf("A Registry with this URL already exists with ID %d", registries.Registries[idx].ID)
+				break
+			}
+		}
+		return []execute.Change{change}, nil
+	}
+
+	for idx := range registries.Registries {
+		current := &registries.Registries[idx]
+		if current.ID != exe.registry.ID {
+			continue
+		}
+		if !isRegistryUpToDate(current, &exe.registry) {
+			return []execute.Change{{Name: exe.GetName(), Action: execute.UpdateAction}}, nil
+		}
+		if exe.registry.Password != nil && *exe.registry.Password != "" {
+			return []execute.Change{{Name: exe.GetName(), Action: execute.UpdateAction, Detail: "Password cannot be compared with the Controller"}}, nil
+		}
+		return []execute.Change{{Name: exe.GetName(), Action: execute.NoOpAction}}, nil
+	}
+	return nil, util.NewNotFoundError(fmt.Sprintf("Could not find Registry with ID %d", exe.registry.ID))
+}
+
+func isRegistryUpToDate(current *client.RegistryInfo, registry *rsc.Registry) bool {
+	if registry.URL != nil && *registry.URL != current.URL {
+		return false
+	}
+	if registry.Private != nil && *registry.Private == current.IsPublic {
+		return false
+	}
+	if registry.Certificate != nil && *registry.Certificate != current.Certificate {
+		return false
+	}
+	if registry.RequiresCert != nil && *registry.RequiresCert != current.RequiresCert {
+		return false
+	}
+	if registry.Username != nil && *registry.Username != current.Username {
+		return false
+	}
+	if registry.Email != nil && *registry.Email != current.Email {
+		return false
+	}
+	return true
+}
+
 func NewExecutor(opt Options) (exe execute.Executor, err error) {
 	// Check the namespace exists
 	ns, err := config.GetNamespace(opt.Namespace)
@@ -126,7 +194,7 @@ func NewExecutor(opt Options) (exe execute.Executor, err error) {
 		registry.Private = &Private
 	}
 
-	if err := validate(registry, true); err != nil {
+	if err := Validate(registry, true); err != nil {
 		return nil, err
 	}
 
@@ -136,7 +204,7 @@ func NewExecutor(opt Options) (exe execute.Executor, err error) {
 	}, nil
 }
 
-func validate(opt rsc.Registry, create bool) error {
+func Validate(opt rsc.Registry, create bool) error {
 	if create {
 		if opt.URL == nil || *opt.URL == "" {
 			return util.NewInputError("URL cannot be empty")
@@ -0,0 +1,74 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deploy
+
+import (
+	"bytes"
+	"io/ioutil"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// getFailedDocuments returns the documents of the executors which failed or were skipped, in the order of the input file
+func getFailedDocuments(documents []*document, results []execute.Result) (failed []*document) {
+	isFailed := make(map[resourceKey]bool)
+	for _, result := range results {
+		isFailed[resourceKey{kind: result.Kind, name: result.Name}] = true
+		// Agent configurations generated from Agent documents are deployed again along with the Agent
+		if result.Kind == config.AgentConfigKind {
+			for _, kind := range []config.Kind{config.RemoteAgentKind, config.LocalAgentKind} {
+				isFailed[resourceKey{kind: kind, name: result.Name}] = true
+			}
+		}
+	}
+	for _, doc := range documents {
+		if isFailed[resourceKey{kind: doc.kind, name: doc.name}] {
+			failed = append(failed, doc)
+		}
+	}
+	return
+}
+
+// writeFailedManifest writes the documents of the executors which failed or were skipped to a file which can be deployed again
+func writeFailedManifest(filename string, documents []*document) error {
+	var buf bytes.Buffer
+	for idx, doc := range documents {
+		var spec yaml.MapSlice
+		if err := yaml.Unmarshal(doc.yaml, &spec); err != nil {
+			return util.NewUnmarshalError(err.Error())
+		}
+		header := config.Header{
+			APIVersion: config.LatestAPIVersion,
+			Kind:       doc.kind,
+			Metadata: config.HeaderMetadata{
+				Name:      doc.name,
+				Namespace: doc.namespace,
+				Tags:      doc.tags,
+			},
+			Spec: spec,
+		}
+		docBytes, err := yaml.Marshal(header)
+		if err != nil {
+			return err
+		}
+		if idx > 0 {
+			buf.WriteString("---\n")
+		}
+		buf.Write(docBytes)
+	}
+	return ioutil.WriteFile(filename, buf.Bytes(), 0600)
+}
@@ -0,0 +1,40 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deploy
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+)
+
+func TestGetFailedDocuments(t *testing.T) {
+	documents := []*document{
+		{kind: config.RemoteAgentKind, name: "agent-1"},
+		{kind: config.RemoteAgentKind, name: "agent-2"},
+		{kind: config.ApplicationKind, name: "app"},
+		{kind: config.RouteKind, name: "app/route"},
+	}
+	results := []execute.Result{
+		{Kind: config.AgentConfigKind, Name: "agent-2", Status: execute.FailedStatus, Err: errors.New("failed")},
+		{Kind: config.RouteKind, Name: "app/route", Status: execute.SkippedStatus},
+	}
+
+	failed := getFailedDocuments(documents, results)
+	if len(failed) != 2 || failed[0] != documents[1] || failed[1] != documents[3] {
+		t.Errorf("Expected agent-2 and app/route to have failed, got %v", failed)
+	}
+}
@@ -0,0 +1,40 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deploy
+
+import (
+	"fmt"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+)
+
+// recordRevisions records the spec of each Application deployed successfully as its latest revision
+func recordRevisions(namespace string, documents []*document, results []execute.Result) {
+	succeeded := make(map[resourceKey]bool)
+	for _, result := range results {
+		if result.Status == execute.SucceededStatus {
+			succeeded[resourceKey{kind: result.Kind, name: result.Name}] = true
+		}
+	}
+	for _, doc := range documents {
+		if doc.kind != config.ApplicationKind || !succeeded[resourceKey{kind: doc.kind, name: doc.name}] {
+			continue
+		}
+		if err := config.AddRevision(namespace, doc.kind, doc.name, doc.yaml); err != nil {
+			util.PrintNotify(fmt.Sprintf("Could not record the revision of Application %s: %s", doc.name, err.Error()))
+		}
+	}
+}
@@ -14,6 +14,7 @@
 package deployroute
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
@@ -42,7 +43,7 @@ func (exe *executor) GetName() string {
 	return "deploying Route " + exe.name
 }
 
-func (exe *executor) Execute() (err error) {
+func (exe *executor) Execute(ctx context.Context) (err error) {
 	if _, err = config.GetNamespace(exe.namespace); err != nil {
 		return
 	}
@@ -64,6 +65,33 @@ func (exe *executor) Execute() (err error) {
 	return
 }
 
+func (exe *executor) Plan() ([]execute.Change, error) {
+	name := exe.appName + "/" + exe.name
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		if rsc.IsNoControlPlaneError(err) {
+			return []execute.Change{{Name: name, Action: execute.CreateAction}}, nil
+		}
+		return nil, err
+	}
+
+	route, err := clt.GetRoute(exe.appName, exe.name)
+	if err != nil {
+		if util.IsNotFoundError(err) {
+			return []execute.Change{{Name: name, Action: execute.CreateAction}}, nil
+		}
+		return nil, err
+	}
+	if route.From == exe.route.From && route.To == exe.route.To {
+		return []execute.Change{{Name: name, Action: execute.NoOpAction}}, nil
+	}
+	return []execute.Change{{
+		Name:   name,
+		Action: execute.UpdateAction,
+		Detail: fmt.Sprintf("%s -> %s becomes %s -> %s", route.From, route.To, exe.route.From, exe.route.To),
+	}}, nil
+}
+
 func NewExecutor(opt Options) (execute.Executor, error) {
 	// Unmarshal file
 	var route rsc.Route
@@ -14,9 +14,12 @@
 package deployvolume
 
 import (
+	"context"
+	"fmt"
 	"os"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/diff"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
@@ -39,7 +42,7 @@ func (exe *executor) GetName() string {
 	return "deploying Volume " + exe.Name
 }
 
-func (exe *executor) Execute() error {
+func (exe *executor) Execute(ctx context.Context) error {
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
 		return err
@@ -87,12 +90,28 @@ func (exe *executor) Execute() error {
 			ns:     ns,
 		})
 	}
-	if errs := execute.RunExecutors(executors, exe.GetName()); len(errs) > 0 {
+	if errs := execute.RunExecutors(ctx, executors, exe.GetName()); len(errs) > 0 {
 		return execute.CoalesceErrors(errs)
 	}
 	return nil
 }
 
+func (exe *executor) Plan() ([]execute.Change, error) {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return nil, err
+	}
+	detail := fmt.Sprintf("Copy %s to %s on %d Agent(s)", exe.volume.Source, exe.volume.Destination, len(exe.volume.Agents))
+	if _, err := ns.GetVolume(exe.Name); err != nil {
+		return []execute.Change{{Name: exe.Name, Action: execute.CreateAction, Detail: detail}}, nil
+	}
+	upToDate, err := diff.IsUpToDate(config.VolumeKind, exe.namespace, exe.Name, exe.volume)
+	if err != nil {
+		return nil, err
+	}
+	return []execute.Change{{Name: exe.Name, Action: execute.GetUpdateAction(upToDate), Detail: detail}}, nil
+}
+
 func NewExecutor(opt Options) (execute.Executor, error) {
 	// Unmarshal file
 	var volume rsc.Volume
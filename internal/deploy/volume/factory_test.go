@@ -0,0 +1,66 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package deployvolume
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+)
+
+func planVolume(t *testing.T, yaml string) execute.Action {
+	exe, err := NewExecutor(Options{Namespace: "default", Name: "vol", Yaml: []byte(yaml)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	changes, err := exe.(execute.PlanExecutor).Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 change, got %v", changes)
+	}
+	return changes[0].Action
+}
+
+func TestPlan(t *testing.T) {
+	config.Init(t.TempDir())
+	spec := fmt.Sprintf("source: %s\ndestination: /tmp/dst\npermissions: \"666\"\nagents:\n- agent-1\n", t.TempDir())
+	if action := planVolume(t, spec); action != execute.CreateAction {
+		t.Errorf("Expected a Volume which is not deployed to be created, got %s", action)
+	}
+
+	exe, err := NewExecutor(Options{Namespace: "default", Name: "vol", Yaml: []byte(spec)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ns, err := config.GetNamespace("default")
+	if err != nil {
+		t.Fatal(err)
+	}
+	volume := exe.(*executor).volume
+	ns.UpdateVolume(&volume)
+
+	if action := planVolume(t, spec); action != execute.NoOpAction {
+		t.Errorf("Expected an unchanged Volume to be a no-op, got %s", action)
+	}
+	if action := planVolume(t, spec+"name: vol\n"); action != execute.NoOpAction {
+		t.Errorf("Expected an unchanged Volume to be a no-op, got %s", action)
+	}
+	if action := planVolume(t, spec[:len(spec)-len("- agent-1\n")]+"- agent-2\n"); action != execute.UpdateAction {
+		t.Errorf("Expected a changed Volume to be updated, got %s", action)
+	}
+}
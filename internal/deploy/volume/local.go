@@ -14,6 +14,7 @@
 package deployvolume
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
@@ -31,7 +32,7 @@ func (exe *localExecutor) GetName() string {
 	return "deploying Volume " + exe.volume.Name
 }
 
-func (exe *localExecutor) Execute() error {
+func (exe *localExecutor) Execute(ctx context.Context) error {
 	if len(exe.agents) == 0 {
 		return nil
 	}
@@ -14,6 +14,7 @@
 package deployvolume
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
@@ -31,13 +32,13 @@ func (exe *remoteExecutor) GetName() string {
 	return "deploying Volume " + exe.volume.Name
 }
 
-func (exe *remoteExecutor) Execute() error {
+func (exe *remoteExecutor) Execute(ctx context.Context) error {
 	util.SpinStart("Pushing volumes to Agents")
 	// Transfer files
 	nbAgents := len(exe.agents)
 	ch := make(chan error, nbAgents)
 	for idx := range exe.agents {
-		go exe.execute(idx, ch)
+		go exe.execute(ctx, idx, ch)
 	}
 	for idx := 0; idx < nbAgents; idx++ {
 		if err := <-ch; err != nil {
@@ -49,16 +50,17 @@ func (exe *remoteExecutor) Execute() error {
 	return config.Flush()
 }
 
-func (exe *remoteExecutor) execute(agentIdx int, ch chan error) {
+func (exe *remoteExecutor) execute(ctx context.Context, agentIdx int, ch chan error) {
 	agent := exe.agents[agentIdx]
 
 	// Connect
-	ssh, err := util.NewSecureShellClient(agent.SSH.User, agent.Host, agent.SSH.KeyFile)
+	ssh, err := agent.SSH.NewClient(agent.Host)
 	if err != nil {
 		msg := "failed to initialize SSH client %s.\n%s"
 		ch <- fmt.Errorf(msg, agent.Name, err.Error())
 		return
 	}
+	ssh.SetContext(ctx)
 	if err := ssh.Connect(); err != nil {
 		msg := "failed to Connect to Agent %s.\n%s"
 		ch <- fmt.Errorf(msg, agent.Name, err.Error())
@@ -14,11 +14,12 @@
 package describe
 
 import (
+	"context"
+
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
-	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
 type agentExecutor struct {
@@ -41,25 +42,33 @@ func (exe *agentExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *agentExecutor) Execute() (err error) {
+func (exe *agentExecutor) Execute(ctx context.Context) error {
+	header, err := exe.getHeader()
+	if err != nil {
+		return err
+	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *agentExecutor) getHeader() (header config.Header, err error) {
 	var agent rsc.Agent
 	if exe.useDetached {
 		agent, err = config.GetDetachedAgent(exe.name)
 		if err != nil {
-			return err
+			return header, err
 		}
 	} else {
 		ns, err := config.GetNamespace(exe.namespace)
 		if err != nil {
-			return err
+			return header, err
 		}
 		// Update local cache based on Controller
 		if err := clientutil.SyncAgentInfo(exe.namespace); err != nil {
-			return err
+			return header, err
 		}
 		agent, err = ns.GetAgent(exe.name)
 		if err != nil {
-			return err
+			return header, err
 		}
 	}
 
@@ -70,7 +79,7 @@ func (exe *agentExecutor) Execute() (err error) {
 		// Get Agent configuration
 		agentConfig, tags, err = clientutil.GetAgentConfig(exe.name, exe.namespace)
 		if err != nil {
-			return err
+			return header, err
 		}
 		agent.SetConfig(&agentConfig)
 	}
@@ -82,7 +91,7 @@ func (exe *agentExecutor) Execute() (err error) {
 	case *rsc.RemoteAgent:
 		kind = config.RemoteAgentKind
 	}
-	header := config.Header{
+	header = config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       kind,
 		Metadata: config.HeaderMetadata{
@@ -93,14 +102,5 @@ func (exe *agentExecutor) Execute() (err error) {
 		Spec: agent,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
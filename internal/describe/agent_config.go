@@ -14,10 +14,11 @@
 package describe
 
 import (
+	"context"
+
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
-	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
 type agentConfigExecutor struct {
@@ -38,12 +39,20 @@ func (exe *agentConfigExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *agentConfigExecutor) Execute() error {
-	agentConfig, tags, err := clientutil.GetAgentConfig(exe.name, exe.namespace)
+func (exe *agentConfigExecutor) Execute(ctx context.Context) error {
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
-	header := config.Header{
+	return printHeader(header, exe.filename)
+}
+
+func (exe *agentConfigExecutor) getHeader() (header config.Header, err error) {
+	agentConfig, tags, err := clientutil.GetAgentConfig(exe.name, exe.namespace)
+	if err != nil {
+		return header, err
+	}
+	header = config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.AgentConfigKind,
 		Metadata: config.HeaderMetadata{
@@ -54,14 +63,5 @@ func (exe *agentConfigExecutor) Execute() error {
 		Spec: agentConfig,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
@@ -14,6 +14,7 @@
 package describe
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
@@ -97,10 +98,18 @@ func (exe *applicationExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *applicationExecutor) Execute() error {
+func (exe *applicationExecutor) Execute(ctx context.Context) error {
+	header, err := exe.getHeader()
+	if err != nil {
+		return err
+	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *applicationExecutor) getHeader() (header config.Header, err error) {
 	// Fetch data
 	if err := exe.init(); err != nil {
-		return err
+		return header, err
 	}
 
 	yamlMsvcs := []rsc.Microservice{}
@@ -109,7 +118,7 @@ func (exe *applicationExecutor) Execute() error {
 	for idx := range exe.msvcs {
 		yamlMsvc, err := MapClientMicroserviceToDeployMicroservice(exe.msvcs[idx], exe.client)
 		if err != nil {
-			return err
+			return header, err
 		}
 		// Remove fields
 		yamlMsvc.Flow = nil
@@ -121,7 +130,7 @@ func (exe *applicationExecutor) Execute() error {
 		to, okDest := exe.msvcPerID[route.DestMicroserviceUUID]
 		if okSrc {
 			if !okDest {
-				return util.NewNotFoundError(fmt.Sprintf("Route %s contains a destination microservice that could not be found in the application", route.Name))
+				return header, util.NewNotFoundError(fmt.Sprintf("Route %s contains a destination microservice that could not be found in the application", route.Name))
 			}
 			yamlRoutes = append(yamlRoutes, rsc.Route{
 				Name: route.Name,
@@ -138,7 +147,7 @@ func (exe *applicationExecutor) Execute() error {
 		ID:            exe.flow.ID,
 	}
 
-	header := config.Header{
+	header = config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.ApplicationKind,
 		Metadata: config.HeaderMetadata{
@@ -148,14 +157,5 @@ func (exe *applicationExecutor) Execute() error {
 		Spec: application,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
@@ -14,6 +14,8 @@
 package describe
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
@@ -37,22 +39,29 @@ func (exe *controllerExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *controllerExecutor) Execute() error {
-	ns, err := config.GetNamespace(exe.namespace)
+func (exe *controllerExecutor) Execute(ctx context.Context) error {
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *controllerExecutor) getHeader() (header config.Header, err error) {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return header, err
+	}
 	controlPlane, err := ns.GetControlPlane()
 	if err != nil {
-		return err
+		return header, err
 	}
 	baseController, err := controlPlane.GetController(exe.name)
 	if err != nil {
-		return err
+		return header, err
 	}
 
 	// Generate header
-	var header config.Header
 	switch controller := baseController.(type) {
 	case *rsc.KubernetesController:
 		header = exe.generateControllerHeader(config.KubernetesControllerKind, controller)
@@ -61,19 +70,10 @@ func (exe *controllerExecutor) Execute() error {
 	case *rsc.LocalController:
 		header = exe.generateControllerHeader(config.LocalControllerKind, controller)
 	default:
-		return util.NewInternalError("Could not convert Control Plane to dynamic type")
+		return header, util.NewInternalError("Could not convert Control Plane to dynamic type")
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
 
 func (exe *controllerExecutor) generateControllerHeader(kind config.Kind, controller rsc.Controller) config.Header {
@@ -14,6 +14,8 @@
 package describe
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
@@ -35,18 +37,25 @@ func (exe *controlPlaneExecutor) GetName() string {
 	return exe.namespace
 }
 
-func (exe *controlPlaneExecutor) Execute() error {
-	ns, err := config.GetNamespace(exe.namespace)
+func (exe *controlPlaneExecutor) Execute(ctx context.Context) error {
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *controlPlaneExecutor) getHeader() (header config.Header, err error) {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return header, err
+	}
 	baseControlPlane, err := ns.GetControlPlane()
 	if err != nil {
-		return err
+		return header, err
 	}
 
 	// Generate header
-	var header config.Header
 	switch controlPlane := baseControlPlane.(type) {
 	case *rsc.KubernetesControlPlane:
 		header = exe.generateControlPlaneHeader(config.KubernetesControlPlaneKind, controlPlane)
@@ -55,19 +64,10 @@ func (exe *controlPlaneExecutor) Execute() error {
 	case *rsc.LocalControlPlane:
 		header = exe.generateControlPlaneHeader(config.LocalControlPlaneKind, controlPlane)
 	default:
-		return util.NewInternalError("Could not convert Control Plane to dynamic type")
+		return header, util.NewInternalError("Could not convert Control Plane to dynamic type")
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
 
 func (exe *controlPlaneExecutor) generateControlPlaneHeader(kind config.Kind, controlPlane rsc.ControlPlane) config.Header {
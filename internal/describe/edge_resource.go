@@ -14,12 +14,12 @@
 package describe
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
-	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
 type edgeResourceExecutor struct {
@@ -42,26 +42,34 @@ func (exe *edgeResourceExecutor) GetName() string {
 	return fmt.Sprintf("%s/%s", exe.name, exe.version)
 }
 
-func (exe *edgeResourceExecutor) Execute() error {
-	_, err := config.GetNamespace(exe.namespace)
+func (exe *edgeResourceExecutor) Execute(ctx context.Context) error {
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *edgeResourceExecutor) getHeader() (header config.Header, err error) {
+	_, err = config.GetNamespace(exe.namespace)
+	if err != nil {
+		return header, err
+	}
 
 	// Connect to Controller
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return header, err
 	}
 
 	// Get Edge Resource
 	edge, err := clt.GetHTTPEdgeResourceByName(exe.name, exe.version)
 	if err != nil {
-		return err
+		return header, err
 	}
 
 	// Convert to YAML
-	header := config.Header{
+	header = config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.EdgeResourceKind,
 		Metadata: config.HeaderMetadata{
@@ -80,14 +88,5 @@ func (exe *edgeResourceExecutor) Execute() error {
 		},
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
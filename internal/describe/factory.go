@@ -16,7 +16,9 @@ package describe
 import (
 	"fmt"
 
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
@@ -29,6 +31,12 @@ type Options struct {
 	Version    string
 }
 
+// headerExecutor is implemented by executors which describe a resource as a deployable YAML document
+type headerExecutor interface {
+	execute.Executor
+	getHeader() (config.Header, error)
+}
+
 func NewExecutor(opt *Options) (execute.Executor, error) {
 	switch opt.Resource {
 	case "namespace":
@@ -59,3 +67,70 @@ func NewExecutor(opt *Options) (execute.Executor, error) {
 		return nil, util.NewInputError(fmt.Sprintf("Unknown resources: %s", opt.Resource))
 	}
 }
+
+// GetHeader returns the deployable YAML document of a resource without printing it
+func GetHeader(opt *Options) (header config.Header, err error) {
+	exe, err := NewExecutor(opt)
+	if err != nil {
+		return
+	}
+	headerExe, ok := exe.(headerExecutor)
+	if !ok {
+		return header, util.NewInputError(fmt.Sprintf("Cannot generate a YAML document for resource %s", opt.Resource))
+	}
+	return headerExe.getHeader()
+}
+
+// GetDocumentHeader returns the live document of the resource declared by a YAML document of the given kind and spec,
+// or nil if the resource is not deployed
+func GetDocumentHeader(kind config.Kind, namespace, name string, spec map[string]interface{}) (*config.Header, error) {
+	opt := &Options{
+		Namespace: namespace,
+		Name:      name,
+	}
+	switch kind {
+	case config.KubernetesControlPlaneKind, config.RemoteControlPlaneKind, config.LocalControlPlaneKind:
+		opt.Resource = "controlplane"
+	case config.KubernetesControllerKind, config.RemoteControllerKind, config.LocalControllerKind:
+		opt.Resource = "controller"
+	case config.RemoteAgentKind, config.LocalAgentKind:
+		opt.Resource = "agent"
+	case config.AgentConfigKind:
+		opt.Resource = "agent-config"
+	case config.EdgeResourceKind:
+		opt.Resource = "edge-resource"
+		opt.Version = fmt.Sprint(spec["version"])
+	case config.ApplicationTemplateKind:
+		opt.Resource = "application-template"
+	case config.VolumeKind:
+		opt.Resource = "volume"
+	case config.RegistryKind:
+		// Registries are identified by ID, which is not known before creation
+		id, found := spec["id"]
+		if !found || fmt.Sprint(id) == "0" {
+			return nil, nil
+		}
+		opt.Resource = "registry"
+		opt.Name = fmt.Sprint(id)
+	case config.ApplicationKind:
+		opt.Resource = "application"
+	case config.MicroserviceKind:
+		opt.Resource = "microservice"
+	case config.RouteKind:
+		opt.Resource = "route"
+	default:
+		return nil, util.NewInputError(fmt.Sprintf("Cannot describe resources of kind %s", kind))
+	}
+
+	header, err := GetHeader(opt)
+	if err != nil {
+		if util.IsNotFoundError(err) || rsc.IsNoControlPlaneError(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	// The live resource was looked up by the identity of the document
+	header.Metadata.Namespace = namespace
+	header.Metadata.Name = name
+	return &header, nil
+}
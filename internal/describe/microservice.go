@@ -14,6 +14,9 @@
 package describe
 
 import (
+	"context"
+	"fmt"
+
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
@@ -55,7 +58,7 @@ func (exe *microserviceExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *microserviceExecutor) Execute() error {
+func (exe *microserviceExecutor) Execute(ctx context.Context) error {
 	// Fetch data
 	if err := exe.init(); err != nil {
 		return err
@@ -65,12 +68,31 @@ func (exe *microserviceExecutor) Execute() error {
 		return nil
 	}
 
-	yamlMsvc, err := MapClientMicroserviceToDeployMicroservice(exe.msvc, exe.client)
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
 
-	header := config.Header{
+func (exe *microserviceExecutor) getHeader() (header config.Header, err error) {
+	// Fetch data
+	if exe.msvc == nil {
+		if err := exe.init(); err != nil {
+			return header, err
+		}
+	}
+
+	if util.IsSystemMsvc(exe.msvc) {
+		return header, util.NewInputError(fmt.Sprintf("Microservice %s is a system Microservice", exe.name))
+	}
+
+	yamlMsvc, err := MapClientMicroserviceToDeployMicroservice(exe.msvc, exe.client)
+	if err != nil {
+		return header, err
+	}
+
+	header = config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.MicroserviceKind,
 		Metadata: config.HeaderMetadata{
@@ -80,14 +102,5 @@ func (exe *microserviceExecutor) Execute() error {
 		Spec: yamlMsvc,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
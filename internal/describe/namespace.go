@@ -14,6 +14,8 @@
 package describe
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
@@ -34,7 +36,7 @@ func (exe *namespaceExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *namespaceExecutor) Execute() error {
+func (exe *namespaceExecutor) Execute(ctx context.Context) error {
 	namespace, err := config.GetNamespace(exe.name)
 	if err != nil {
 		return err
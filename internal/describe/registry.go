@@ -14,6 +14,7 @@
 package describe
 
 import (
+	"context"
 	"fmt"
 	"strconv"
 
@@ -46,16 +47,24 @@ func (exe *registryExecutor) GetName() string {
 	return strconv.Itoa(exe.id)
 }
 
-func (exe *registryExecutor) Execute() error {
+func (exe *registryExecutor) Execute(ctx context.Context) error {
+	header, err := exe.getHeader()
+	if err != nil {
+		return err
+	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *registryExecutor) getHeader() (header config.Header, err error) {
 	// Connect to controller
 	ctrl, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return header, err
 	}
 
 	registriesList, err := ctrl.ListRegistries()
 	if err != nil {
-		return err
+		return header, err
 	}
 
 	var registry rsc.Registry
@@ -79,10 +88,10 @@ func (exe *registryExecutor) Execute() error {
 	}
 
 	if registry.ID == 0 {
-		return util.NewNotFoundError(fmt.Sprintf("Could not find registry with ID %d", exe.id))
+		return header, util.NewNotFoundError(fmt.Sprintf("Could not find registry with ID %d", exe.id))
 	}
 
-	header := config.Header{
+	header = config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.RegistryKind,
 		Metadata: config.HeaderMetadata{
@@ -91,14 +100,5 @@ func (exe *registryExecutor) Execute() error {
 		Spec: registry,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
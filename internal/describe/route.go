@@ -14,10 +14,11 @@
 package describe
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
-	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
 type routeExecutor struct {
@@ -38,41 +39,49 @@ func (exe *routeExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *routeExecutor) Execute() error {
-	_, err := config.GetNamespace(exe.namespace)
+func (exe *routeExecutor) Execute(ctx context.Context) error {
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *routeExecutor) getHeader() (header config.Header, err error) {
+	_, err = config.GetNamespace(exe.namespace)
+	if err != nil {
+		return header, err
+	}
 
 	// Connect to Controller
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
-		return err
+		return header, err
 	}
 
 	appName, routeName, err := clientutil.ParseFQName(exe.name, "Route")
 	if err != nil {
-		return err
+		return header, err
 	}
 
 	// Get Route
 	route, err := clt.GetRoute(appName, routeName)
 	if err != nil {
-		return err
+		return header, err
 	}
 
 	// Convert route details
 	from, err := clientutil.GetMicroserviceName(exe.namespace, route.SourceMicroserviceUUID)
 	if err != nil {
-		return err
+		return header, err
 	}
 	to, err := clientutil.GetMicroserviceName(exe.namespace, route.DestMicroserviceUUID)
 	if err != nil {
-		return err
+		return header, err
 	}
 
 	// Convert to YAML
-	header := config.Header{
+	header = config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.RouteKind,
 		Metadata: config.HeaderMetadata{
@@ -86,14 +95,5 @@ func (exe *routeExecutor) Execute() error {
 		},
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
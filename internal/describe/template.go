@@ -14,9 +14,10 @@
 package describe
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
-	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
 type applicationTemplateExecutor struct {
@@ -37,20 +38,28 @@ func (exe *applicationTemplateExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *applicationTemplateExecutor) Execute() error {
-	clt, err := clientutil.NewControllerClient(exe.namespace)
+func (exe *applicationTemplateExecutor) Execute(ctx context.Context) error {
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *applicationTemplateExecutor) getHeader() (header config.Header, err error) {
+	clt, err := clientutil.NewControllerClient(exe.namespace)
+	if err != nil {
+		return header, err
+	}
 
 	template, err := clt.GetApplicationTemplate(exe.name)
 	if err != nil {
-		return err
+		return header, err
 	}
 
-	header := config.Header{
+	header = config.Header{
 		APIVersion: config.LatestAPIVersion,
-		Kind:       config.ApplicationKind,
+		Kind:       config.ApplicationTemplateKind,
 		Metadata: config.HeaderMetadata{
 			Namespace: exe.namespace,
 			Name:      exe.name,
@@ -58,14 +67,5 @@ func (exe *applicationTemplateExecutor) Execute() error {
 		Spec: template,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
@@ -20,6 +20,7 @@ import (
 
 	apps "github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/apps"
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
@@ -199,3 +200,10 @@ func mapExtraHosts(in []client.MicroserviceExtraHost) (out []apps.MicroserviceEx
 	}
 	return
 }
+
+func printHeader(header config.Header, filename string) error {
+	if filename == "" {
+		return util.Print(header)
+	}
+	return util.FPrint(header, filename)
+}
@@ -14,8 +14,9 @@
 package describe
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
-	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
 type volumeExecutor struct {
@@ -36,17 +37,25 @@ func (exe *volumeExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *volumeExecutor) Execute() (err error) {
-	ns, err := config.GetNamespace(exe.namespace)
+func (exe *volumeExecutor) Execute(ctx context.Context) error {
+	header, err := exe.getHeader()
 	if err != nil {
 		return err
 	}
+	return printHeader(header, exe.filename)
+}
+
+func (exe *volumeExecutor) getHeader() (header config.Header, err error) {
+	ns, err := config.GetNamespace(exe.namespace)
+	if err != nil {
+		return header, err
+	}
 	volume, err := ns.GetVolume(exe.name)
 	if err != nil {
-		return err
+		return header, err
 	}
 
-	header := config.Header{
+	header = config.Header{
 		APIVersion: config.LatestAPIVersion,
 		Kind:       config.VolumeKind,
 		Metadata: config.HeaderMetadata{
@@ -56,14 +65,5 @@ func (exe *volumeExecutor) Execute() (err error) {
 		Spec: volume,
 	}
 
-	if exe.filename == "" {
-		if err := util.Print(header); err != nil {
-			return err
-		}
-	} else {
-		if err := util.FPrint(header, exe.filename); err != nil {
-			return err
-		}
-	}
-	return nil
+	return header, nil
 }
@@ -14,6 +14,7 @@
 package detachagent
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
@@ -37,7 +38,7 @@ func (exe executor) GetName() string {
 	return exe.name
 }
 
-func (exe executor) Execute() error {
+func (exe executor) Execute(ctx context.Context) error {
 	util.SpinStart("Detaching Agent")
 
 	// Check doesn't already exist with same name
@@ -85,11 +86,11 @@ iofogctl rename agent %s %s-2 -n %s --detached`
 	// Deprovision agent
 	switch agent := baseAgent.(type) {
 	case *rsc.LocalAgent:
-		if err := exe.localDeprovision(); err != nil {
+		if err := exe.localDeprovision(ctx); err != nil {
 			return err
 		}
 	case *rsc.RemoteAgent:
-		if err := exe.remoteDeprovision(agent); err != nil {
+		if err := exe.remoteDeprovision(ctx, agent); err != nil {
 			return err
 		}
 	}
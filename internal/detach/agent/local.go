@@ -14,13 +14,14 @@
 package detachagent
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog/install"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
-func (exe executor) localDeprovision() error {
+func (exe executor) localDeprovision(ctx context.Context) error {
 	containerClient, err := install.NewLocalContainerClient()
 	if err != nil {
 		util.PrintNotify(fmt.Sprintf("Could not deprovision local iofog-agent container. Error: %s\n", err.Error()))
@@ -31,5 +32,6 @@ func (exe executor) localDeprovision() error {
 	}); err != nil {
 		util.PrintNotify(fmt.Sprintf("Could not deprovision local iofog-agent container. Error: %s\n", err.Error()))
 	}
+	containerClient.SetContext(ctx)
 	return nil
 }
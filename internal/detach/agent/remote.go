@@ -14,6 +14,7 @@
 package detachagent
 
 import (
+	"context"
 	"fmt"
 
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
@@ -21,7 +22,7 @@ import (
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
-func (exe executor) remoteDeprovision(agent *rsc.RemoteAgent) error {
+func (exe executor) remoteDeprovision(ctx context.Context, agent *rsc.RemoteAgent) error {
 	if agent.ValidateSSH() != nil {
 		util.PrintNotify("Could not deprovision daemon for Agent " + agent.Name + ". SSH details missing from local configuration. Use configure command to add SSH details.")
 	} else {
@@ -29,12 +30,16 @@ func (exe executor) remoteDeprovision(agent *rsc.RemoteAgent) error {
 			agent.SSH.User,
 			agent.Host,
 			agent.SSH.Port,
-			agent.SSH.KeyFile,
+			agent.SSH.GetAuth(),
 			agent.Name,
 			agent.UUID)
 		if err != nil {
 			return err
 		}
+		sshAgent.SetContext(ctx)
+		sshAgent.SetHostKey(agent.SSH.HostKey)
+		sshAgent.SetJumpHosts(agent.SSH.GetJumpHosts())
+		sshAgent.SetPrivilege(agent.SSH.GetPrivilege())
 		if err := sshAgent.Deprovision(); err != nil {
 			util.PrintNotify(fmt.Sprintf("Failed to deprovision daemon on Agent %s. %s", agent.Name, err.Error()))
 		}
@@ -14,6 +14,7 @@
 package detachedgeresource
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
@@ -40,7 +41,7 @@ func (exe executor) GetName() string {
 	return fmt.Sprintf("%s/%s", exe.name, exe.version)
 }
 
-func (exe executor) Execute() error {
+func (exe executor) Execute(ctx context.Context) error {
 	util.SpinStart("Detaching Edge Resource")
 
 	// Init client
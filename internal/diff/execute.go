@@ -0,0 +1,99 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package diff
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+)
+
+var kindOrder = []config.Kind{
+	config.KubernetesControlPlaneKind,
+	config.RemoteControlPlaneKind,
+	config.LocalControlPlaneKind,
+	config.RemoteControllerKind,
+	config.LocalControllerKind,
+	config.RemoteAgentKind,
+	config.LocalAgentKind,
+	config.AgentConfigKind,
+	config.EdgeResourceKind,
+	config.ApplicationTemplateKind,
+	config.VolumeKind,
+	config.RegistryKind,
+	config.ApplicationKind,
+	config.MicroserviceKind,
+	config.RouteKind,
+}
+
+// kindHandlers holds every kind which can be described
+var kindHandlers = map[config.Kind]func(*execute.KindHandlerOpt) (execute.Executor, error){
+	config.KubernetesControlPlaneKind: newExecutor,
+	config.RemoteControlPlaneKind:     newExecutor,
+	config.LocalControlPlaneKind:      newExecutor,
+	config.RemoteControllerKind:       newExecutor,
+	config.LocalControllerKind:        newExecutor,
+	config.RemoteAgentKind:            newExecutor,
+	config.LocalAgentKind:             newExecutor,
+	config.AgentConfigKind:            newExecutor,
+	config.EdgeResourceKind:           newExecutor,
+	config.ApplicationTemplateKind:    newExecutor,
+	config.VolumeKind:                 newExecutor,
+	config.RegistryKind:               newExecutor,
+	config.ApplicationKind:            newExecutor,
+	config.MicroserviceKind:           newExecutor,
+	config.RouteKind:                  newExecutor,
+}
+
+type Options struct {
+	Namespace string
+	Input     execute.InputOptions
+}
+
+func Execute(ctx context.Context, opt *Options) error {
+	executorsMap, err := execute.GetExecutorsFromYAML(&opt.Input, opt.Namespace, kindHandlers)
+	if err != nil {
+		return err
+	}
+
+	// Compare all documents with the live state
+	for _, kind := range kindOrder {
+		if errs := execute.RunExecutors(ctx, executorsMap[kind], fmt.Sprintf("diff %s", kind)); len(errs) > 0 {
+			return execute.CoalesceErrors(errs)
+		}
+	}
+
+	// Print differences in the order of the deployment
+	hasDiff := false
+	for _, kind := range kindOrder {
+		for _, baseExe := range executorsMap[kind] {
+			exe, ok := baseExe.(*executor)
+			if !ok {
+				return util.NewInternalError("Could not convert executor to diff executor")
+			}
+			if exe.diff == "" {
+				continue
+			}
+			hasDiff = true
+			fmt.Print(exe.diff)
+		}
+	}
+	if !hasDiff {
+		util.PrintInfo("No differences found")
+	}
+	return nil
+}
@@ -0,0 +1,71 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package diff
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/describe"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+type executor struct {
+	namespace string
+	kind      config.Kind
+	name      string
+	tags      *[]string
+	spec      map[string]interface{}
+	diff      string
+}
+
+func newExecutor(opt *execute.KindHandlerOpt) (execute.Executor, error) {
+	spec := make(map[string]interface{})
+	if err := yaml.Unmarshal(opt.YAML, &spec); err != nil {
+		return nil, util.NewUnmarshalError(err.Error())
+	}
+	return &executor{
+		namespace: opt.Namespace,
+		kind:      opt.Kind,
+		name:      opt.Name,
+		tags:      opt.Tags,
+		spec:      spec,
+	}, nil
+}
+
+func (exe *executor) GetName() string {
+	return exe.name
+}
+
+func (exe *executor) Execute(ctx context.Context) (err error) {
+	desired := config.Header{
+		APIVersion: config.LatestAPIVersion,
+		Kind:       exe.kind,
+		Metadata: config.HeaderMetadata{
+			Namespace: exe.namespace,
+			Name:      exe.name,
+			Tags:      exe.tags,
+		},
+		Spec: exe.spec,
+	}
+	live, err := describe.GetDocumentHeader(exe.kind, exe.namespace, exe.name, exe.spec)
+	if err != nil {
+		return err
+	}
+	exe.diff, err = unifiedDiff(live, &desired, fmt.Sprintf("%s/%s", exe.kind, exe.name))
+	return err
+}
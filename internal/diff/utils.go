@@ -0,0 +1,131 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package diff
+
+import (
+	"reflect"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/describe"
+	"github.com/pmezard/go-difflib/difflib"
+	"gopkg.in/yaml.v2"
+)
+
+// unifiedDiff returns the unified diff between the live and desired documents, or an empty string if they match.
+// A nil live document is treated as a resource which is not deployed yet
+func unifiedDiff(live, desired *config.Header, name string) (string, error) {
+	desiredDoc, err := toGeneric(desired)
+	if err != nil {
+		return "", err
+	}
+	desiredBytes, err := yaml.Marshal(desiredDoc)
+	if err != nil {
+		return "", err
+	}
+
+	liveText := ""
+	if live != nil {
+		liveDoc, err := toGeneric(live)
+		if err != nil {
+			return "", err
+		}
+		liveBytes, err := yaml.Marshal(prune(liveDoc, desiredDoc))
+		if err != nil {
+			return "", err
+		}
+		liveText = string(liveBytes)
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(liveText),
+		B:        difflib.SplitLines(string(desiredBytes)),
+		FromFile: "live/" + name,
+		ToFile:   "file/" + name,
+		Context:  3,
+	})
+}
+
+// IsUpToDate returns true if the live resource declared by a document of the kind matches the spec of the document,
+// i.e. the diff command would show no difference. It returns false if the resource is not deployed
+func IsUpToDate(kind config.Kind, namespace, name string, spec interface{}) (bool, error) {
+	bytes, err := yaml.Marshal(spec)
+	if err != nil {
+		return false, err
+	}
+	specMap := make(map[string]interface{})
+	if err := yaml.Unmarshal(bytes, &specMap); err != nil {
+		return false, err
+	}
+	live, err := describe.GetDocumentHeader(kind, namespace, name, specMap)
+	if err != nil || live == nil {
+		return false, err
+	}
+	return specMatches(live.Spec, spec)
+}
+
+// specMatches returns true if the live spec has the fields of the desired spec, with the same values
+func specMatches(live, desired interface{}) (bool, error) {
+	liveSpec, err := toGeneric(live)
+	if err != nil {
+		return false, err
+	}
+	desiredSpec, err := toGeneric(desired)
+	if err != nil {
+		return false, err
+	}
+	return reflect.DeepEqual(prune(liveSpec, desiredSpec), desiredSpec), nil
+}
+
+// toGeneric converts a document to maps, slices and scalars so documents decoded into different types can be compared
+func toGeneric(in interface{}) (out interface{}, err error) {
+	bytes, err := yaml.Marshal(in)
+	if err != nil {
+		return
+	}
+	err = yaml.Unmarshal(bytes, &out)
+	return
+}
+
+// prune removes the fields of the live document which are not set in the desired document,
+// such as IDs and defaults generated by the Controller
+func prune(live, desired interface{}) interface{} {
+	switch desiredVal := desired.(type) {
+	case map[interface{}]interface{}:
+		liveVal, ok := live.(map[interface{}]interface{})
+		if !ok {
+			return live
+		}
+		for key := range liveVal {
+			desiredField, found := desiredVal[key]
+			if !found {
+				delete(liveVal, key)
+				continue
+			}
+			liveVal[key] = prune(liveVal[key], desiredField)
+		}
+		return liveVal
+	case []interface{}:
+		liveVal, ok := live.([]interface{})
+		if !ok {
+			return live
+		}
+		for idx := range liveVal {
+			if idx < len(desiredVal) {
+				liveVal[idx] = prune(liveVal[idx], desiredVal[idx])
+			}
+		}
+		return liveVal
+	}
+	return live
+}
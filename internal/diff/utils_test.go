@@ -0,0 +1,93 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package diff
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	desired := &config.Header{
+		APIVersion: config.LatestAPIVersion,
+		Kind:       config.VolumeKind,
+		Metadata:   config.HeaderMetadata{Name: "vol"},
+		Spec: map[string]interface{}{
+			"source":      "/tmp/src",
+			"destination": "/tmp/dst",
+			"agents":      []interface{}{"agent-1"},
+		},
+	}
+	live := &config.Header{
+		APIVersion: config.LatestAPIVersion,
+		Kind:       config.VolumeKind,
+		Metadata:   config.HeaderMetadata{Name: "vol"},
+		Spec: map[string]interface{}{
+			"source":      "/tmp/src",
+			"destination": "/tmp/dst",
+			"agents":      []interface{}{"agent-1"},
+			"permissions": "666",
+		},
+	}
+
+	// Fields only set on the live resource are ignored
+	diff, err := unifiedDiff(live, desired, "Volume/vol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff != "" {
+		t.Errorf("Expected no differences, got:\n%s", diff)
+	}
+
+	live.Spec.(map[string]interface{})["destination"] = "/tmp/other"
+	diff, err = unifiedDiff(live, desired, "Volume/vol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "-  destination: /tmp/other\n") || !strings.Contains(diff, "+  destination: /tmp/dst\n") {
+		t.Errorf("Expected destination to differ, got:\n%s", diff)
+	}
+
+	// Resources which are not deployed are entirely added
+	diff, err = unifiedDiff(nil, desired, "Volume/vol")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "+kind: Volume\n") || strings.Contains(diff, "\n-") {
+		t.Errorf("Expected only additions, got:\n%s", diff)
+	}
+}
+
+func TestSpecMatches(t *testing.T) {
+	desired := map[string]interface{}{
+		"microservices": []interface{}{
+			map[string]interface{}{"name": "msvc", "images": map[string]interface{}{"x86": "app:1.0.0"}},
+		},
+	}
+	live := map[string]interface{}{
+		"microservices": []interface{}{
+			map[string]interface{}{"name": "msvc", "uuid": "abc", "images": map[string]interface{}{"x86": "app:1.0.0", "arm": ""}},
+		},
+	}
+	if matches, err := specMatches(live, desired); err != nil || !matches {
+		t.Errorf("Expected an unchanged spec to match, got %v, %v", matches, err)
+	}
+
+	desired["microservices"].([]interface{})[0].(map[string]interface{})["images"] = map[string]interface{}{"x86": "app:2.0.0"}
+	if matches, err := specMatches(live, desired); err != nil || matches {
+		t.Errorf("Expected a changed spec not to match, got %v, %v", matches, err)
+	}
+}
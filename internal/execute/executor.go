@@ -13,11 +13,13 @@
 
 package execute
 
+import "context"
+
 type Executor interface {
-	Execute() error
+	Execute(ctx context.Context) error
 	GetName() string
 }
 
 type ProvisioningExecutor interface {
-	ProvisionAgent() (string, error)
+	ProvisionAgent(ctx context.Context) (string, error)
 }
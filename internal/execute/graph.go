@@ -0,0 +1,208 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"github.com/twmb/algoimpl/go/graph"
+)
+
+// Graph runs executors in parallel while respecting the dependencies between them
+type Graph struct {
+	nodes  []*GraphNode
+	report *Report
+}
+
+// GraphNode is an executor of a Graph
+type GraphNode struct {
+	kind         config.Kind
+	name         string
+	exe          Executor
+	dependencies map[*GraphNode]bool
+	dependents   []*GraphNode
+}
+
+// NewGraph returns an empty graph. The results of its executors are added to the report, if any
+func NewGraph(report *Report) *Graph {
+	return &Graph{
+		report: report,
+	}
+}
+
+// AddNode adds an executor of a kind to the graph
+func (g *Graph) AddNode(kind config.Kind, name string, exe Executor) *GraphNode {
+	exe = NewRetryExecutor(exe, kind, GetRetryPolicy(kind))
+	if g.report != nil {
+		exe = &reportingExecutor{
+			Executor: exe,
+			kind:     kind,
+			name:     name,
+			report:   g.report,
+		}
+	}
+	node := &GraphNode{
+		kind:         kind,
+		name:         name,
+		exe:          exe,
+		dependencies: make(map[*GraphNode]bool),
+	}
+	g.nodes = append(g.nodes, node)
+	return node
+}
+
+func (node *GraphNode) String() string {
+	return fmt.Sprintf("%s %s", node.kind, node.name)
+}
+
+// AddDependency makes the node wait for the dependency to succeed before executing
+func (g *Graph) AddDependency(node, dependency *GraphNode) {
+	if node == dependency || node.dependencies[dependency] {
+		return
+	}
+	node.dependencies[dependency] = true
+	dependency.dependents = append(dependency.dependents, node)
+}
+
+type graphResult struct {
+	node *GraphNode
+	err  error
+}
+
+// Execute runs each executor as soon as all of its dependencies succeeded, within the parallelism limits.
+// When an executor fails, only the executors which depend on it are skipped.
+// Executors which have not started when the context is cancelled are skipped
+func (g *Graph) Execute(ctx context.Context) (errs []error) {
+	if err := g.checkCycles(); err != nil {
+		return []error{err}
+	}
+
+	results := make(chan graphResult, len(g.nodes))
+	pending := make(map[*GraphNode]int)
+	skipped := make(map[*GraphNode]bool)
+	started := make(map[*GraphNode]bool)
+	ready := []*GraphNode{}
+	running := 0
+	runningKind := make(map[config.Kind]int)
+	// schedule starts the ready executors until a limit is reached
+	schedule := func() {
+		if ctx.Err() != nil {
+			return
+		}
+		waiting := []*GraphNode{}
+		for _, node := range ready {
+			if !canRun(node.kind, running, runningKind) {
+				waiting = append(waiting, node)
+				continue
+			}
+			running++
+			runningKind[node.kind]++
+			started[node] = true
+			go func(node *GraphNode) {
+				results <- graphResult{
+					node: node,
+					err:  node.exe.Execute(ctx),
+				}
+			}(node)
+		}
+		ready = waiting
+	}
+
+	for _, node := range g.nodes {
+		pending[node] = len(node.dependencies)
+		if pending[node] == 0 {
+			ready = append(ready, node)
+		}
+	}
+	schedule()
+
+	markSkipped := func(node *GraphNode, err error) {
+		skipped[node] = true
+		errs = append(errs, err)
+		if g.report != nil {
+			g.report.Add(Result{
+				Kind:   node.kind,
+				Name:   node.name,
+				Status: SkippedStatus,
+				Err:    err,
+			})
+		}
+	}
+	var skip func(node, failed *GraphNode)
+	skip = func(node, failed *GraphNode) {
+		for _, dependent := range node.dependents {
+			if skipped[dependent] {
+				continue
+			}
+			markSkipped(dependent, util.NewError(fmt.Sprintf("Skipped %s because %s failed", dependent, failed)))
+			skip(dependent, failed)
+		}
+	}
+
+	for running > 0 {
+		result := <-results
+		running--
+		runningKind[result.node.kind]--
+		if result.err != nil {
+			errs = append(errs, result.err)
+			skip(result.node, result.node)
+		} else {
+			for _, dependent := range result.node.dependents {
+				pending[dependent]--
+				if pending[dependent] == 0 && !skipped[dependent] {
+					ready = append(ready, dependent)
+				}
+			}
+		}
+		schedule()
+	}
+
+	if ctx.Err() != nil {
+		for _, node := range g.nodes {
+			if !started[node] && !skipped[node] {
+				markSkipped(node, util.NewError(fmt.Sprintf("Skipped %s: %s", node, ctx.Err().Error())))
+			}
+		}
+	}
+	return errs
+}
+
+func (g *Graph) checkCycles() error {
+	sortGraph := graph.New(graph.Directed)
+	sortNodes := make(map[*GraphNode]graph.Node)
+	for _, node := range g.nodes {
+		sortNodes[node] = sortGraph.MakeNode()
+		*sortNodes[node].Value = node
+	}
+	for _, node := range g.nodes {
+		for dependency := range node.dependencies {
+			if err := sortGraph.MakeEdge(sortNodes[dependency], sortNodes[node]); err != nil {
+				return err
+			}
+		}
+	}
+	for _, component := range sortGraph.StronglyConnectedComponents() {
+		if len(component) > 1 {
+			names := []string{}
+			for _, sortNode := range component {
+				names = append(names, (*sortNode.Value).(*GraphNode).String())
+			}
+			return util.NewInputError(fmt.Sprintf("Cyclic dependencies between resources: %v", names))
+		}
+	}
+	return nil
+}
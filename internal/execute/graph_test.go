@@ -0,0 +1,112 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+)
+
+type recordingExecutor struct {
+	name string
+	err  error
+	mux  *sync.Mutex
+	done *[]string
+}
+
+func (exe *recordingExecutor) GetName() string {
+	return exe.name
+}
+
+func (exe *recordingExecutor) Execute(ctx context.Context) error {
+	exe.mux.Lock()
+	defer exe.mux.Unlock()
+	*exe.done = append(*exe.done, exe.name)
+	return exe.err
+}
+
+func TestGraphExecute(t *testing.T) {
+	mux := &sync.Mutex{}
+	done := []string{}
+	newExe := func(name string, err error) Executor {
+		return &recordingExecutor{name: name, err: err, mux: mux, done: &done}
+	}
+
+	g := NewGraph(nil)
+	agent := g.AddNode(config.MicroserviceKind, "agent", newExe("agent", nil))
+	app := g.AddNode(config.MicroserviceKind, "app", newExe("app", nil))
+	msvc := g.AddNode(config.MicroserviceKind, "msvc", newExe("msvc", nil))
+	route := g.AddNode(config.MicroserviceKind, "route", newExe("route", nil))
+	volume := g.AddNode(config.MicroserviceKind, "volume", newExe("volume", errors.New("volume failed")))
+	other := g.AddNode(config.MicroserviceKind, "other", newExe("other", nil))
+	g.AddDependency(msvc, agent)
+	g.AddDependency(msvc, app)
+	g.AddDependency(route, msvc)
+	g.AddDependency(other, volume)
+
+	errs := g.Execute(context.Background())
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+	if errs[1].Error() != "Skipped Microservice other because Microservice volume failed" {
+		t.Errorf("Unexpected error: %s", errs[1].Error())
+	}
+
+	position := make(map[string]int)
+	for idx, name := range done {
+		position[name] = idx
+	}
+	if _, found := position["other"]; found {
+		t.Error("Expected other to be skipped")
+	}
+	if len(done) != 5 {
+		t.Fatalf("Expected 5 executors to run, got %v", done)
+	}
+	if position["msvc"] < position["agent"] || position["msvc"] < position["app"] || position["route"] < position["msvc"] {
+		t.Errorf("Dependencies were not respected: %v", done)
+	}
+}
+
+func TestGraphCycle(t *testing.T) {
+	g := NewGraph(nil)
+	a := g.AddNode(config.MicroserviceKind, "a", NewEmptyExecutor("a"))
+	b := g.AddNode(config.MicroserviceKind, "b", NewEmptyExecutor("b"))
+	g.AddDependency(a, b)
+	g.AddDependency(b, a)
+	if errs := g.Execute(context.Background()); len(errs) != 1 {
+		t.Errorf("Expected cyclic dependency error, got %v", errs)
+	}
+}
+
+func TestGraphCancelled(t *testing.T) {
+	mux := &sync.Mutex{}
+	done := []string{}
+	g := NewGraph(nil)
+	g.AddNode(config.MicroserviceKind, "a", &recordingExecutor{name: "a", mux: mux, done: &done})
+	g.AddNode(config.MicroserviceKind, "b", &recordingExecutor{name: "b", mux: mux, done: &done})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	errs := g.Execute(ctx)
+	if len(errs) != 2 {
+		t.Fatalf("Expected 2 errors, got %v", errs)
+	}
+	if len(done) != 0 {
+		t.Errorf("Expected no executor to run, got %v", done)
+	}
+}
@@ -0,0 +1,212 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+)
+
+// StdinInput is the path which reads the YAML documents from stdin
+const StdinInput = "-"
+
+// Reader of StdinInput, replaced in tests
+var stdin io.Reader = os.Stdin
+
+// Input is the content of a YAML file, or of stdin
+type Input struct {
+	Name string
+	Data []byte
+}
+
+// InputOptions are the YAML files and overlay to read, and the values to render them with
+type InputOptions struct {
+	Files       []string
+	Overlay     string
+	Recursive   bool
+	ValuesFiles []string
+	Set         []string
+	Env         bool
+}
+
+// IsEmpty returns whether no YAML file nor overlay is provided
+func (opt *InputOptions) IsEmpty() bool {
+	return len(opt.Files) == 0 && opt.Overlay == ""
+}
+
+// ReadsStdin returns whether the YAML documents are read from stdin
+func (opt *InputOptions) ReadsStdin() bool {
+	for _, file := range opt.Files {
+		if file == StdinInput {
+			return true
+		}
+	}
+	return false
+}
+
+// ReadInputs reads the input files and renders them with the values, then reads the overlay.
+// The files are only rendered if values or the env fallback are provided. See ReadInputFiles, Values.Render and readOverlay
+func ReadInputs(opt *InputOptions) (inputs []Input, err error) {
+	if opt.IsEmpty() {
+		return nil, util.NewInputError("No input file provided via the -f flag nor overlay via the -k flag")
+	}
+	values, err := GetValues(opt.ValuesFiles, opt.Set, opt.Env)
+	if err != nil {
+		return nil, err
+	}
+	if len(opt.Files) > 0 {
+		if inputs, err = readRenderedFiles(opt.Files, opt.Recursive, values); err != nil {
+			return nil, err
+		}
+	}
+	if opt.Overlay != "" {
+		overlayInputs, err := readOverlay(opt.Overlay, opt.Recursive, values, make(map[string]bool))
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, overlayInputs...)
+	}
+	return inputs, nil
+}
+
+func readRenderedFiles(paths []string, recursive bool, values *Values) ([]Input, error) {
+	inputs, err := ReadInputFiles(paths, recursive)
+	if err != nil {
+		return nil, err
+	}
+	for idx := range inputs {
+		if inputs[idx].Data, err = values.Render(inputs[idx].Name, inputs[idx].Data); err != nil {
+			return nil, err
+		}
+	}
+	return inputs, nil
+}
+
+// ReadInputFiles reads the YAML files at the paths, in order. A path is a file, a directory, a glob, or - for stdin.
+// Only the .yaml and .yml files of a directory are read, including those of its subdirectories if recursive is set
+func ReadInputFiles(paths []string, recursive bool) (inputs []Input, err error) {
+	if len(paths) == 0 {
+		return nil, util.NewInputError("No input file provided via the -f flag")
+	}
+	filenames := []string{}
+	read := make(map[string]bool)
+	readStdin := false
+	for _, path := range paths {
+		if path == StdinInput {
+			if readStdin {
+				return nil, util.NewInputError("Cannot read stdin more than once")
+			}
+			readStdin = true
+			filenames = append(filenames, StdinInput)
+			continue
+		}
+		matches, err := expandInputPath(path, recursive)
+		if err != nil {
+			return nil, err
+		}
+		for _, filename := range matches {
+			filename = filepath.Clean(filename)
+			if read[filename] {
+				continue
+			}
+			read[filename] = true
+			filenames = append(filenames, filename)
+		}
+	}
+
+	for _, filename := range filenames {
+		var data []byte
+		if filename == StdinInput {
+			data, err = ioutil.ReadAll(stdin)
+		} else {
+			data, err = ioutil.ReadFile(filename)
+		}
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, Input{Name: filename, Data: data})
+	}
+	return inputs, nil
+}
+
+// expandInputPath returns the files of a path which is a file, a directory or a glob.
+// Existing files and directories are read as they are, even if their name contains glob characters, e.g. app[1].yaml
+func expandInputPath(path string, recursive bool) ([]string, error) {
+	if _, err := os.Stat(path); err != nil && strings.ContainsAny(path, "*?[") {
+		matches, err := filepath.Glob(path)
+		if err != nil {
+			return nil, util.NewInputError(fmt.Sprintf("Invalid glob %s: %s", path, err.Error()))
+		}
+		if len(matches) == 0 {
+			return nil, util.NewInputError(fmt.Sprintf("No file matches %s", path))
+		}
+		filenames := []string{}
+		for _, match := range matches {
+			// Directories matched by a glob are read like directories passed explicitly
+			matchFilenames, err := expandFilePath(match, recursive)
+			if err != nil {
+				return nil, err
+			}
+			filenames = append(filenames, matchFilenames...)
+		}
+		return filenames, nil
+	}
+	return expandFilePath(path, recursive)
+}
+
+// expandFilePath returns the path if it is a file, or the YAML files of the directory
+func expandFilePath(path string, recursive bool) ([]string, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return []string{path}, nil
+	}
+
+	filenames := []string{}
+	err = filepath.Walk(path, func(filename string, fileInfo os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if fileInfo.IsDir() {
+			if filename != path && !recursive {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if isYAMLFile(filename) {
+			filenames = append(filenames, filename)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(filenames) == 0 {
+		return nil, util.NewInputError(fmt.Sprintf("Directory %s does not contain any YAML file", path))
+	}
+	return filenames, nil
+}
+
+func isYAMLFile(filename string) bool {
+	ext := strings.ToLower(filepath.Ext(filename))
+	return ext == ".yaml" || ext == ".yml"
+}
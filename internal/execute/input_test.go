@@ -0,0 +1,88 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestReadInputFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"agents/a.yaml":          "a",
+		"agents/b.yml":           "b",
+		"agents/README.md":       "readme",
+		"agents/nested/c.yaml":   "c",
+		"apps/app.yaml":          "app",
+		"brackets/app[1].yaml":   "bracket",
+		"routes/route.yaml":      "route",
+		"routes/ignored.yaml~":   "ignored",
+		"routes/other-route.yml": "other",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	stdin = strings.NewReader("stdin")
+	defer func() { stdin = os.Stdin }()
+
+	read := func(paths []string, recursive bool) string {
+		inputs, err := ReadInputFiles(paths, recursive)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents := []string{}
+		for _, input := range inputs {
+			contents = append(contents, string(input.Data))
+		}
+		return strings.Join(contents, ",")
+	}
+
+	if got := read([]string{filepath.Join(dir, "agents")}, false); got != "a,b" {
+		t.Errorf("Unexpected directory contents: %s", got)
+	}
+	if got := read([]string{filepath.Join(dir, "agents")}, true); got != "a,b,c" {
+		t.Errorf("Unexpected recursive directory contents: %s", got)
+	}
+	if got := read([]string{filepath.Join(dir, "routes", "*.yml"), filepath.Join(dir, "apps", "app.yaml"), "-"}, false); got != "other,app,stdin" {
+		t.Errorf("Unexpected contents: %s", got)
+	}
+	if got := read([]string{filepath.Join(dir, "*"), filepath.Join(dir, "apps")}, false); got != "a,b,app,bracket,other,route" {
+		t.Errorf("Unexpected glob contents: %s", got)
+	}
+
+	// Files with glob characters in their name are read as they are, and when matched by a glob
+	if got := read([]string{filepath.Join(dir, "brackets", "app[1].yaml")}, false); got != "bracket" {
+		t.Errorf("Unexpected bracketed file contents: %s", got)
+	}
+	if got := read([]string{filepath.Join(dir, "brackets", "*.yaml")}, false); got != "bracket" {
+		t.Errorf("Unexpected bracketed glob contents: %s", got)
+	}
+
+	if _, err := ReadInputFiles([]string{filepath.Join(dir, "*.json")}, false); err == nil {
+		t.Error("Expected error for a glob without matches")
+	}
+	if _, err := ReadInputFiles([]string{"-", "-"}, false); err == nil {
+		t.Error("Expected error for reading stdin twice")
+	}
+}
@@ -0,0 +1,356 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	jsonpatch "github.com/evanphx/json-patch"
+	"gopkg.in/yaml.v2"
+)
+
+// OverlayFilename is the file read from the directories of overlays
+const OverlayFilename = "overlay.yaml"
+
+// overlay patches the documents of its resources, which are YAML files, directories, globs or other overlays
+type overlay struct {
+	Resources []string       `yaml:"resources"`
+	Patches   []overlayPatch `yaml:"patches"`
+}
+
+// overlayPatch is read from a file or inline. A list of operations is a JSON patch applied to the target,
+// otherwise each document of the patch is merged into the document of the same kind and name
+type overlayPatch struct {
+	Path   string         `yaml:"path"`
+	Patch  string         `yaml:"patch"`
+	Target *overlayTarget `yaml:"target"`
+}
+
+type overlayTarget struct {
+	Kind string `yaml:"kind"`
+	Name string `yaml:"name"`
+}
+
+// overlayDocument is a decoded document of an input file
+type overlayDocument struct {
+	kind    string
+	name    string
+	value   interface{}
+	patched bool
+}
+
+// readOverlay reads the resources of the overlay at the path, a directory containing an overlay.yaml file or the file itself,
+// then applies its patches to their documents. Resources and patches are rendered with the values
+func readOverlay(path string, recursive bool, values *Values, visited map[string]bool) (inputs []Input, err error) {
+	filename := path
+	if isOverlayDir(path) {
+		filename = filepath.Join(path, OverlayFilename)
+	}
+	filename = filepath.Clean(filename)
+	if visited[filename] {
+		return nil, util.NewInputError(fmt.Sprintf("Overlay %s includes itself", filename))
+	}
+	visited[filename] = true
+	defer delete(visited, filename)
+
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	if data, err = values.Render(filename, data); err != nil {
+		return nil, err
+	}
+	ovl := overlay{}
+	if err := yaml.UnmarshalStrict(data, &ovl); err != nil {
+		return nil, util.NewUnmarshalError(fmt.Sprintf("Failed to decode overlay %s: %s", filename, err.Error()))
+	}
+
+	// Read the resources relative to the overlay
+	dir := filepath.Dir(filename)
+	for _, resource := range ovl.Resources {
+		if !filepath.IsAbs(resource) {
+			resource = filepath.Join(dir, resource)
+		}
+		var resourceInputs []Input
+		if isOverlayDir(resource) {
+			resourceInputs, err = readOverlay(resource, recursive, values, visited)
+		} else {
+			resourceInputs, err = readRenderedFiles([]string{resource}, recursive, values)
+		}
+		if err != nil {
+			return nil, err
+		}
+		inputs = append(inputs, resourceInputs...)
+	}
+
+	// Decode the documents to patch them
+	documents := make([][]*overlayDocument, len(inputs))
+	for idx := range inputs {
+		if documents[idx], err = decodeOverlayDocuments(inputs[idx]); err != nil {
+			return nil, err
+		}
+	}
+	for _, patch := range ovl.Patches {
+		if err := applyOverlayPatch(dir, patch, values, documents); err != nil {
+			return nil, util.NewInputError(fmt.Sprintf("Failed to apply patch of overlay %s: %s", filename, err.Error()))
+		}
+	}
+
+	// Encode the patched documents, leaving the other files untouched
+	for idx := range inputs {
+		if inputs[idx].Data, err = encodeOverlayDocuments(inputs[idx].Data, documents[idx]); err != nil {
+			return nil, err
+		}
+	}
+	return inputs, nil
+}
+
+func isOverlayDir(path string) bool {
+	info, err := os.Stat(filepath.Join(path, OverlayFilename))
+	return err == nil && !info.IsDir()
+}
+
+func decodeOverlayDocuments(input Input) (documents []*overlayDocument, err error) {
+	dec := yaml.NewDecoder(bytes.NewReader(input.Data))
+	for {
+		var value interface{}
+		if err = dec.Decode(&value); err != nil {
+			break
+		}
+		if value == nil {
+			continue
+		}
+		documents = append(documents, newOverlayDocument(toJSONValue(value)))
+	}
+	if err != io.EOF {
+		return nil, util.NewUnmarshalError(fmt.Sprintf("Failed to decode %s: %s", input.Name, err.Error()))
+	}
+	return documents, nil
+}
+
+func newOverlayDocument(value interface{}) *overlayDocument {
+	doc := &overlayDocument{value: value}
+	if fields, ok := value.(map[string]interface{}); ok {
+		doc.kind, _ = fields["kind"].(string)
+		if metadata, ok := fields["metadata"].(map[string]interface{}); ok {
+			doc.name, _ = metadata["name"].(string)
+		}
+	}
+	return doc
+}
+
+func encodeOverlayDocuments(data []byte, documents []*overlayDocument) ([]byte, error) {
+	patched := false
+	for _, doc := range documents {
+		patched = patched || doc.patched
+	}
+	if !patched {
+		return data, nil
+	}
+	var buffer bytes.Buffer
+	for idx, doc := range documents {
+		if idx > 0 {
+			buffer.WriteString("---\n")
+		}
+		docData, err := yaml.Marshal(doc.value)
+		if err != nil {
+			return nil, err
+		}
+		buffer.Write(docData)
+	}
+	return buffer.Bytes(), nil
+}
+
+func applyOverlayPatch(dir string, patch overlayPatch, values *Values, documents [][]*overlayDocument) (err error) {
+	if (patch.Path == "") == (patch.Patch == "") {
+		return util.NewInputError("A patch requires either a path or an inline patch")
+	}
+	name := "inline patch"
+	data := []byte(patch.Patch)
+	if patch.Path != "" {
+		name = patch.Path
+		if !filepath.IsAbs(name) {
+			name = filepath.Join(dir, name)
+		}
+		if data, err = ioutil.ReadFile(name); err != nil {
+			return err
+		}
+	}
+	if data, err = values.Render(name, data); err != nil {
+		return err
+	}
+	patchDocuments, err := decodeOverlayDocuments(Input{Name: name, Data: data})
+	if err != nil {
+		return err
+	}
+
+	for _, patchDoc := range patchDocuments {
+		if operations, isJSONPatch := patchDoc.value.([]interface{}); isJSONPatch {
+			if patch.Target == nil {
+				return util.NewInputError(fmt.Sprintf("JSON patch %s requires a target", name))
+			}
+			err = patchTarget(documents, patch.Target.Kind, patch.Target.Name, func(doc *overlayDocument) error {
+				return applyJSONPatch(doc, operations)
+			})
+		} else {
+			kind, docName := patchDoc.kind, patchDoc.name
+			if patch.Target != nil {
+				kind, docName = patch.Target.Kind, patch.Target.Name
+			}
+			err = patchTarget(documents, kind, docName, func(doc *overlayDocument) error {
+				doc.value = mergePatch(doc.value, patchDoc.value)
+				return nil
+			})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// patchTarget patches the documents of the kind and name
+func patchTarget(documents [][]*overlayDocument, kind, name string, patch func(*overlayDocument) error) error {
+	found := false
+	for _, inputDocuments := range documents {
+		for _, doc := range inputDocuments {
+			if doc.kind != kind || doc.name != name {
+				continue
+			}
+			if err := patch(doc); err != nil {
+				return err
+			}
+			*doc = *newOverlayDocument(doc.value)
+			doc.patched = true
+			found = true
+		}
+	}
+	if !found {
+		return util.NewNotFoundError(fmt.Sprintf("Could not find the %s named %s to patch", kind, name))
+	}
+	return nil
+}
+
+func applyJSONPatch(doc *overlayDocument, operations []interface{}) error {
+	patchData, err := json.Marshal(operations)
+	if err != nil {
+		return err
+	}
+	jsonPatch, err := jsonpatch.DecodePatch(patchData)
+	if err != nil {
+		return err
+	}
+	docData, err := json.Marshal(doc.value)
+	if err != nil {
+		return err
+	}
+	if docData, err = jsonPatch.Apply(docData); err != nil {
+		return err
+	}
+	return json.Unmarshal(docData, &doc.value)
+}
+
+// mergePatch merges the patch into the value. Maps are merged recursively and null values delete their key.
+// Lists of maps are merged by their name or key field, and items with $patch: delete are removed. Other lists are replaced
+func mergePatch(value, patch interface{}) interface{} {
+	switch patch := patch.(type) {
+	case map[string]interface{}:
+		fields, ok := value.(map[string]interface{})
+		if !ok {
+			fields = make(map[string]interface{})
+		}
+		for key, patchValue := range patch {
+			if patchValue == nil {
+				delete(fields, key)
+				continue
+			}
+			fields[key] = mergePatch(fields[key], patchValue)
+		}
+		return fields
+	case []interface{}:
+		items, ok := value.([]interface{})
+		mergeKey := getMergeKey(items, patch)
+		if !ok || mergeKey == "" {
+			return patch
+		}
+		for _, patchItem := range patch {
+			patchFields := patchItem.(map[string]interface{})
+			deleteItem := patchFields["$patch"] == "delete"
+			delete(patchFields, "$patch")
+			matched := false
+			for idx := 0; idx < len(items); idx++ {
+				if items[idx].(map[string]interface{})[mergeKey] != patchFields[mergeKey] {
+					continue
+				}
+				matched = true
+				if deleteItem {
+					items = append(items[:idx], items[idx+1:]...)
+					idx--
+					continue
+				}
+				items[idx] = mergePatch(items[idx], patchFields)
+			}
+			if !matched && !deleteItem {
+				items = append(items, patchFields)
+			}
+		}
+		return items
+	default:
+		return patch
+	}
+}
+
+// getMergeKey returns the field identifying the items of both lists, or an empty string if they are not all maps with such a field
+func getMergeKey(items, patchItems []interface{}) string {
+	for _, key := range []string{"name", "key"} {
+		hasKey := true
+		for _, item := range append(append([]interface{}{}, items...), patchItems...) {
+			fields, ok := item.(map[string]interface{})
+			if !ok || fields[key] == nil {
+				hasKey = false
+				break
+			}
+		}
+		if hasKey {
+			return key
+		}
+	}
+	return ""
+}
+
+// toJSONValue converts the maps decoded from YAML to maps with string keys
+func toJSONValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case map[interface{}]interface{}:
+		fields := make(map[string]interface{})
+		for key, fieldValue := range value {
+			fields[fmt.Sprint(key)] = toJSONValue(fieldValue)
+		}
+		return fields
+	case []interface{}:
+		for idx := range value {
+			value[idx] = toJSONValue(value[idx])
+		}
+		return value
+	default:
+		return value
+	}
+}
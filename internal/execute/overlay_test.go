@@ -0,0 +1,132 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v2"
+)
+
+func TestOverlay(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"base/agent.yaml": `apiVersion: iofog.org/v3
+kind: Agent
+metadata:
+  name: agent-1
+spec:
+  host: 10.0.0.1
+  ssh:
+    user: foo
+`,
+		"base/app.yaml": `apiVersion: iofog.org/v3
+kind: Application
+metadata:
+  name: app
+spec:
+  microservices:
+  - name: msvc-1
+    container:
+      env:
+      - key: LEVEL
+        value: debug
+  - name: msvc-2
+---
+apiVersion: iofog.org/v3
+kind: Route
+metadata:
+  name: route
+spec:
+  from: msvc-1
+  to: msvc-2
+`,
+		"prod/overlay.yaml": `resources:
+- ../base
+patches:
+- path: agent.yaml
+- target:
+    kind: Application
+    name: app
+  patch: |
+    - op: replace
+      path: /metadata/name
+      value: app-${env}
+`,
+		"prod/agent.yaml": `kind: Agent
+metadata:
+  name: agent-1
+spec:
+  host: 10.0.1.1
+---
+kind: Application
+metadata:
+  name: app
+spec:
+  microservices:
+  - name: msvc-1
+    container:
+      env:
+      - key: LEVEL
+        value: info
+  - name: msvc-2
+    $patch: delete
+`,
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := ioutil.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	inputs, err := ReadInputs(&InputOptions{Overlay: filepath.Join(dir, "prod"), Set: []string{"env=prod"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) != 2 {
+		t.Fatalf("Expected 2 inputs, got %d", len(inputs))
+	}
+	if string(inputs[0].Data) != "apiVersion: iofog.org/v3\nkind: Agent\nmetadata:\n  name: agent-1\nspec:\n  host: 10.0.1.1\n  ssh:\n    user: foo\n" {
+		t.Errorf("Unexpected Agent:\n%s", string(inputs[0].Data))
+	}
+
+	documents, err := decodeOverlayDocuments(inputs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(documents) != 2 || documents[0].name != "app-prod" || documents[1].name != "route" {
+		t.Fatalf("Unexpected documents: %v", documents)
+	}
+	app, err := yaml.Marshal(documents[0].value.(map[string]interface{})["spec"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(app) != "microservices:\n- container:\n    env:\n    - key: LEVEL\n      value: info\n  name: msvc-1\n" {
+		t.Errorf("Unexpected Application spec:\n%s", string(app))
+	}
+
+	if err := ioutil.WriteFile(filepath.Join(dir, "prod", "overlay.yaml"), []byte("resources:\n- ../base\npatches:\n- path: missing.yaml\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ReadInputs(&InputOptions{Overlay: filepath.Join(dir, "prod")}); err == nil {
+		t.Error("Expected error for a missing patch file")
+	}
+}
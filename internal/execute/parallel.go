@@ -14,9 +14,12 @@
 package execute
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"sync"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
 type jobResult struct {
@@ -36,39 +39,25 @@ func CoalesceErrors(errs []error) error {
 	return errors.New(msg)
 }
 
-func RunExecutors(executors []Executor, execType string) []error {
-	if errs, _ := ForParallel(executors); len(errs) > 0 {
+func RunExecutors(ctx context.Context, executors []Executor, execType string) []error {
+	if errs, _ := ForParallel(ctx, executors); len(errs) > 0 {
 		return errs
 	}
 	return []error{}
 }
 
-func ForParallel(exes []Executor) (errs []error, failedExes []Executor) {
-	// Instantiate wait group for parallel tasks
-	var wg sync.WaitGroup
-	// Deploy controllers
-	errChan := make(chan jobResult, len(exes))
-	for idx := range exes {
-		wg.Add(1)
-		go func(exe Executor) {
-			defer wg.Done()
-			err := exe.Execute()
-			errChan <- jobResult{
-				err: err,
-				exe: exe,
-			}
-		}(exes[idx])
-	}
-	wg.Wait()
-	close(errChan)
+// ForParallel runs the executors in parallel, limited by the global parallelism.
+// Executors which have not started when the context is cancelled are not executed
+func ForParallel(ctx context.Context, exes []Executor) (errs []error, failedExes []Executor) {
+	return runPool(ctx, exes, getWorkerCount("", len(exes)))
+}
 
-	// Output any errors
-	for result := range errChan {
-		if result.err != nil {
-			errs = append(errs, result.err)
-			failedExes = append(failedExes, result.exe)
-		}
-	}
+// ForParallelKind runs the executors of a kind in parallel, limited by the global and kind parallelism
+func ForParallelKind(ctx context.Context, exes []Executor, kind config.Kind) (errs []error, failedExes []Executor) {
+	return runPool(ctx, exes, getWorkerCount(kind, len(exes)))
+}
 
-	return
+// NewCancelledError returns the error of an executor which was not executed because the context was cancelled
+func NewCancelledError(ctx context.Context, name string) error {
+	return util.NewError(fmt.Sprintf("Did not execute %s: %s", name, ctx.Err().Error()))
 }
@@ -0,0 +1,101 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+)
+
+// Action is the type of change an executor would apply to a resource
+type Action string
+
+const (
+	CreateAction Action = "create"
+	UpdateAction Action = "update"
+	NoOpAction   Action = "no-op"
+	DeleteAction Action = "delete"
+)
+
+// Change describes a single change an executor would apply to a resource
+type Change struct {
+	Kind   config.Kind
+	Name   string
+	Action Action
+	Detail string
+}
+
+// PlanExecutor is implemented by executors that can report their intended changes
+// against the current state without modifying anything
+type PlanExecutor interface {
+	Executor
+	Plan() ([]Change, error)
+}
+
+// GetUpdateAction returns the action deploying a resource which exists, a no-op if it is up to date
+func GetUpdateAction(upToDate bool) Action {
+	if upToDate {
+		return NoOpAction
+	}
+	return UpdateAction
+}
+
+func (exe *emptyExecutor) Plan() ([]Change, error) {
+	return []Change{}, nil
+}
+
+// PlanExecutors collects the changes of all executors in parallel, preserving the order of the executors.
+// The kind is used for any change that does not specify one
+func PlanExecutors(exes []Executor, kind config.Kind) (changes []Change, errs []error) {
+	var wg sync.WaitGroup
+	results := make([][]Change, len(exes))
+	errResults := make([]error, len(exes))
+	for idx := range exes {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			results[idx], errResults[idx] = planExecutor(exes[idx], kind)
+		}(idx)
+	}
+	wg.Wait()
+
+	for idx := range exes {
+		if errResults[idx] != nil {
+			errs = append(errs, errResults[idx])
+			continue
+		}
+		changes = append(changes, results[idx]...)
+	}
+	return
+}
+
+func planExecutor(exe Executor, kind config.Kind) ([]Change, error) {
+	planExe, ok := exe.(PlanExecutor)
+	if !ok {
+		return nil, util.NewInternalError(fmt.Sprintf("Cannot plan changes of %s %s", kind, exe.GetName()))
+	}
+	changes, err := planExe.Plan()
+	if err != nil {
+		return nil, err
+	}
+	for idx := range changes {
+		if changes[idx].Kind == "" {
+			changes[idx].Kind = kind
+		}
+	}
+	return changes, nil
+}
@@ -0,0 +1,111 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"context"
+	"sync"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+)
+
+var (
+	parallelismMutex sync.RWMutex
+	// Maximum number of executors running at once, 0 is unlimited
+	parallelism int
+	// Maximum number of executors of a kind running at once, 0 is unlimited
+	kindParallelism = make(map[config.Kind]int)
+)
+
+// SetParallelism limits the number of executors running at once. 0 removes the limit
+func SetParallelism(limit int) {
+	parallelismMutex.Lock()
+	defer parallelismMutex.Unlock()
+	parallelism = limit
+}
+
+// SetKindParallelism limits the number of executors of a kind running at once. 0 removes the limit
+func SetKindParallelism(kind config.Kind, limit int) {
+	parallelismMutex.Lock()
+	defer parallelismMutex.Unlock()
+	kindParallelism[kind] = limit
+}
+
+// getParallelism returns the global limit and the limit of the kind
+func getParallelism(kind config.Kind) (global, perKind int) {
+	parallelismMutex.RLock()
+	defer parallelismMutex.RUnlock()
+	return parallelism, kindParallelism[kind]
+}
+
+// getWorkerCount returns the number of workers to run executors of a kind with, which is the lowest of the limits
+func getWorkerCount(kind config.Kind, jobCount int) int {
+	workers := jobCount
+	global, perKind := getParallelism(kind)
+	for _, limit := range []int{global, perKind} {
+		if limit > 0 && limit < workers {
+			workers = limit
+		}
+	}
+	return workers
+}
+
+// canRun returns true if another executor of the kind can start without exceeding the limits
+func canRun(kind config.Kind, running int, runningKind map[config.Kind]int) bool {
+	global, perKind := getParallelism(kind)
+	if global > 0 && running >= global {
+		return false
+	}
+	return perKind <= 0 || runningKind[kind] < perKind
+}
+
+// runPool executes the executors with a pool of workers
+func runPool(ctx context.Context, exes []Executor, workers int) (errs []error, failedExes []Executor) {
+	jobs := make(chan Executor)
+	results := make(chan jobResult, len(exes))
+	var wg sync.WaitGroup
+	for idx := 0; idx < workers; idx++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for exe := range jobs {
+				var err error
+				if ctx.Err() != nil {
+					err = NewCancelledError(ctx, exe.GetName())
+				} else {
+					err = exe.Execute(ctx)
+				}
+				results <- jobResult{
+					err: err,
+					exe: exe,
+				}
+			}
+		}()
+	}
+	for idx := range exes {
+		jobs <- exes[idx]
+	}
+	close(jobs)
+	wg.Wait()
+	close(results)
+
+	// Output any errors
+	for result := range results {
+		if result.err != nil {
+			errs = append(errs, result.err)
+			failedExes = append(failedExes, result.exe)
+		}
+	}
+	return
+}
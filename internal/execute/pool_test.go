@@ -0,0 +1,75 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+)
+
+type concurrencyExecutor struct {
+	mux     *sync.Mutex
+	running *int
+	max     *int
+}
+
+func (exe *concurrencyExecutor) GetName() string {
+	return "concurrent"
+}
+
+func (exe *concurrencyExecutor) Execute(ctx context.Context) error {
+	exe.mux.Lock()
+	*exe.running++
+	if *exe.running > *exe.max {
+		*exe.max = *exe.running
+	}
+	exe.mux.Unlock()
+	time.Sleep(5 * time.Millisecond)
+	exe.mux.Lock()
+	*exe.running--
+	exe.mux.Unlock()
+	return nil
+}
+
+func TestParallelism(t *testing.T) {
+	defer SetParallelism(0)
+	defer SetKindParallelism(config.VolumeKind, 0)
+	SetParallelism(3)
+	SetKindParallelism(config.VolumeKind, 2)
+
+	mux := &sync.Mutex{}
+	running, max := 0, 0
+	exes := []Executor{}
+	g := NewGraph(nil)
+	for idx := 0; idx < 10; idx++ {
+		exes = append(exes, &concurrencyExecutor{mux: mux, running: &running, max: &max})
+		g.AddNode(config.VolumeKind, "volume", exes[idx])
+	}
+
+	if errs, _ := ForParallel(context.Background(), exes); len(errs) > 0 || max != 3 {
+		t.Errorf("Expected 3 executors at once, got %d", max)
+	}
+	max = 0
+	if errs, _ := ForParallelKind(context.Background(), exes, config.VolumeKind); len(errs) > 0 || max != 2 {
+		t.Errorf("Expected 2 Volumes at once, got %d", max)
+	}
+	max = 0
+	if errs := g.Execute(context.Background()); len(errs) > 0 || max != 2 {
+		t.Errorf("Expected 2 Volumes at once in the graph, got %d", max)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+)
+
+// Status is the outcome of an executor
+type Status string
+
+const (
+	SucceededStatus Status = "succeeded"
+	FailedStatus    Status = "failed"
+	SkippedStatus   Status = "skipped"
+)
+
+// Result is the outcome of a single executor
+type Result struct {
+	Kind     config.Kind
+	Name     string
+	Status   Status
+	Duration time.Duration
+	Err      error
+}
+
+// Report collects the results of executors. It is safe for concurrent use
+type Report struct {
+	mux     sync.Mutex
+	results []Result
+}
+
+func NewReport() *Report {
+	return &Report{}
+}
+
+func (report *Report) Add(result Result) {
+	report.mux.Lock()
+	defer report.mux.Unlock()
+	report.results = append(report.results, result)
+}
+
+// Results returns the results in the order they were added
+func (report *Report) Results() []Result {
+	report.mux.Lock()
+	defer report.mux.Unlock()
+	return append([]Result{}, report.results...)
+}
+
+// Unsuccessful returns the results of the executors which failed or were skipped
+func (report *Report) Unsuccessful() (results []Result) {
+	for _, result := range report.Results() {
+		if result.Status != SucceededStatus {
+			results = append(results, result)
+		}
+	}
+	return
+}
+
+// Print writes the results as a table
+func (report *Report) Print(out io.Writer) error {
+	writer := tabwriter.NewWriter(out, 16, 8, 1, '\t', 0)
+	if _, err := fmt.Fprintf(writer, "KIND\tNAME\tSTATUS\tDURATION\tERROR\t\n"); err != nil {
+		return err
+	}
+	for _, result := range report.Results() {
+		errMsg := ""
+		if result.Err != nil {
+			errMsg = strings.ReplaceAll(strings.TrimSpace(result.Err.Error()), "\n", " ")
+		}
+		duration := "-"
+		if result.Status != SkippedStatus {
+			duration = result.Duration.Round(time.Millisecond).String()
+		}
+		if _, err := fmt.Fprintf(writer, "%s\t%s\t%s\t%s\t%s\t\n", result.Kind, result.Name, result.Status, duration, errMsg); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
+
+// reportingExecutor adds the result of the executor it wraps to a report
+type reportingExecutor struct {
+	Executor
+	kind   config.Kind
+	name   string
+	report *Report
+}
+
+// NewReportingExecutor returns an executor which adds the result of exe to the report once executed
+func NewReportingExecutor(exe Executor, kind config.Kind, report *Report) Executor {
+	return &reportingExecutor{
+		Executor: exe,
+		kind:     kind,
+		name:     exe.GetName(),
+		report:   report,
+	}
+}
+
+func (exe *reportingExecutor) Execute(ctx context.Context) error {
+	start := time.Now()
+	err := exe.Executor.Execute(ctx)
+	result := Result{
+		Kind:     exe.kind,
+		Name:     exe.name,
+		Status:   SucceededStatus,
+		Duration: time.Since(start),
+		Err:      err,
+	}
+	if err != nil {
+		result.Status = FailedStatus
+	}
+	exe.report.Add(result)
+	return err
+}
+
+// RunReportedExecutors runs executors of a kind in parallel following the retry policy of the kind,
+// and adds their results to the report
+func RunReportedExecutors(ctx context.Context, exes []Executor, kind config.Kind, report *Report) []error {
+	reportingExes := make([]Executor, len(exes))
+	policy := GetRetryPolicy(kind)
+	for idx := range exes {
+		reportingExes[idx] = NewReportingExecutor(NewRetryExecutor(exes[idx], kind, policy), kind, report)
+	}
+	if errs, _ := ForParallelKind(ctx, reportingExes, kind); len(errs) > 0 {
+		return errs
+	}
+	return []error{}
+}
@@ -0,0 +1,162 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+)
+
+// RetryPolicy defines how executors are executed again after failing
+type RetryPolicy struct {
+	// Attempts is the total number of executions, 1 disables retries
+	Attempts int
+	// Backoff is the delay before the first retry, doubled after each retry
+	Backoff time.Duration
+	// MaxBackoff caps the delay between retries
+	MaxBackoff time.Duration
+	// Retryable returns true if the error is worth retrying. IsRetryableError is used if nil
+	Retryable func(error) bool
+}
+
+var (
+	retryMutex sync.RWMutex
+	// Policy used for kinds without their own policy
+	retryPolicy = RetryPolicy{
+		Attempts:   1,
+		Backoff:    time.Second,
+		MaxBackoff: 30 * time.Second,
+	}
+	kindRetryPolicies = make(map[config.Kind]RetryPolicy)
+)
+
+// SetRetryPolicy sets the policy of every kind without its own policy
+func SetRetryPolicy(policy RetryPolicy) {
+	retryMutex.Lock()
+	defer retryMutex.Unlock()
+	retryPolicy = policy
+}
+
+// SetKindRetryPolicy sets the policy of a kind
+func SetKindRetryPolicy(kind config.Kind, policy RetryPolicy) {
+	retryMutex.Lock()
+	defer retryMutex.Unlock()
+	kindRetryPolicies[kind] = policy
+}
+
+// GetRetryPolicy returns the policy of a kind
+func GetRetryPolicy(kind config.Kind) RetryPolicy {
+	retryMutex.RLock()
+	defer retryMutex.RUnlock()
+	if policy, exists := kindRetryPolicies[kind]; exists {
+		return policy
+	}
+	return retryPolicy
+}
+
+// Transient failures reported by HTTP and SSH clients
+var retryableMessages = []string{
+	"timeout",
+	"timed out",
+	"connection refused",
+	"connection reset",
+	"broken pipe",
+	"no route to host",
+	"EOF",
+	"Bad Gateway",
+	"Service Unavailable",
+	"Gateway Timeout",
+	"context deadline exceeded",
+}
+
+// IsRetryableError returns true if the error is likely to be transient, e.g. a network failure or a 5xx response
+func IsRetryableError(err error) bool {
+	if err == nil {
+		return false
+	}
+	var httpErr *util.HTTPError
+	if errors.As(err, &httpErr) {
+		return isRetryableStatusCode(httpErr.Code)
+	}
+	var clientHTTPErr *client.HTTPError
+	if errors.As(err, &clientHTTPErr) {
+		return isRetryableStatusCode(clientHTTPErr.Code)
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return true
+	}
+	for _, msg := range retryableMessages {
+		if strings.Contains(err.Error(), msg) {
+			return true
+		}
+	}
+	return false
+}
+
+func isRetryableStatusCode(code int) bool {
+	return code >= 500 || code == 408 || code == 429
+}
+
+// retryExecutor executes the executor it wraps again while it fails with a retryable error
+type retryExecutor struct {
+	Executor
+	kind   config.Kind
+	policy RetryPolicy
+	sleep  func(context.Context, time.Duration) error
+}
+
+// NewRetryExecutor returns an executor which follows the policy when exe fails
+func NewRetryExecutor(exe Executor, kind config.Kind, policy RetryPolicy) Executor {
+	if policy.Attempts <= 1 {
+		return exe
+	}
+	return &retryExecutor{
+		Executor: exe,
+		kind:     kind,
+		policy:   policy,
+		sleep:    util.Sleep,
+	}
+}
+
+func (exe *retryExecutor) Execute(ctx context.Context) (err error) {
+	retryable := exe.policy.Retryable
+	if retryable == nil {
+		retryable = IsRetryableError
+	}
+	backoff := exe.policy.Backoff
+	for attempt := 1; ; attempt++ {
+		err = exe.Executor.Execute(ctx)
+		if err == nil || attempt >= exe.policy.Attempts || ctx.Err() != nil || !retryable(err) {
+			return err
+		}
+		util.PrintNotify(fmt.Sprintf("%s %s failed, retrying in %s (attempt %d of %d)\n%s", exe.kind, exe.GetName(), backoff, attempt+1, exe.policy.Attempts, err.Error()))
+		if sleepErr := exe.sleep(ctx, backoff); sleepErr != nil {
+			return err
+		}
+		backoff *= 2
+		if exe.policy.MaxBackoff > 0 && backoff > exe.policy.MaxBackoff {
+			backoff = exe.policy.MaxBackoff
+		}
+	}
+}
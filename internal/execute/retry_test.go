@@ -0,0 +1,96 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+)
+
+type flakyExecutor struct {
+	errs     []error
+	attempts int
+}
+
+func (exe *flakyExecutor) GetName() string {
+	return "flaky"
+}
+
+func (exe *flakyExecutor) Execute(ctx context.Context) error {
+	exe.attempts++
+	if exe.attempts <= len(exe.errs) {
+		return exe.errs[exe.attempts-1]
+	}
+	return nil
+}
+
+func TestRetryExecutor(t *testing.T) {
+	transient := util.NewHTTPError("Received 502", 502)
+	policy := RetryPolicy{Attempts: 3, Backoff: time.Second, MaxBackoff: 3 * time.Second}
+
+	// Succeeds on the last attempt, doubling the backoff
+	flaky := &flakyExecutor{errs: []error{transient, transient}}
+	exe := NewRetryExecutor(flaky, config.MicroserviceKind, policy).(*retryExecutor)
+	delays := []time.Duration{}
+	exe.sleep = func(_ context.Context, delay time.Duration) error {
+		delays = append(delays, delay)
+		return nil
+	}
+	if err := exe.Execute(context.Background()); err != nil {
+		t.Errorf("Expected success, got %s", err.Error())
+	}
+	if flaky.attempts != 3 || len(delays) != 2 || delays[0] != time.Second || delays[1] != 2*time.Second {
+		t.Errorf("Unexpected attempts %d and delays %v", flaky.attempts, delays)
+	}
+
+	// Errors which are not retryable fail immediately
+	flaky = &flakyExecutor{errs: []error{util.NewInputError("Invalid spec")}}
+	exe = NewRetryExecutor(flaky, config.MicroserviceKind, policy).(*retryExecutor)
+	exe.sleep = func(context.Context, time.Duration) error { return nil }
+	if err := exe.Execute(context.Background()); err == nil || flaky.attempts != 1 {
+		t.Errorf("Expected a single failed attempt, got %d", flaky.attempts)
+	}
+
+	// No retries leaves the executor untouched
+	if NewRetryExecutor(flaky, config.MicroserviceKind, RetryPolicy{Attempts: 1}) != Executor(flaky) {
+		t.Error("Expected the executor to be returned as is")
+	}
+}
+
+func TestIsRetryableError(t *testing.T) {
+	for _, err := range []error{
+		util.NewHTTPError("", 503),
+		util.NewHTTPError("", 429),
+		errors.New("dial tcp 10.0.0.1:51121: connect: connection refused"),
+	} {
+		if !IsRetryableError(err) {
+			t.Errorf("Expected %v to be retryable", err)
+		}
+	}
+	for _, err := range []error{
+		nil,
+		util.NewHTTPError("", 400),
+		util.NewNotFoundError("agent"),
+		util.NewInputError("Invalid spec"),
+	} {
+		if IsRetryableError(err) {
+			t.Errorf("Expected %v not to be retryable", err)
+		}
+	}
+}
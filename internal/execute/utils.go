@@ -15,9 +15,9 @@ package execute
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"io"
-	"io/ioutil"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
@@ -28,7 +28,7 @@ type emptyExecutor struct {
 	name string
 }
 
-func (exe *emptyExecutor) Execute() error {
+func (exe *emptyExecutor) Execute(ctx context.Context) error {
 	return nil
 }
 func (exe *emptyExecutor) GetName() string {
@@ -61,21 +61,29 @@ func generateExecutor(header *config.Header, namespace string, kindHandlers map[
 		return exe, err
 	}
 
-	createExecutorFunc, found := kindHandlers[header.Kind]
-	if !found {
-		util.PrintNotify(fmt.Sprintf("Could not handle kind %s. Skipping document\n", header.Kind))
-		return nil, nil
-	}
-
-	return createExecutorFunc(&KindHandlerOpt{
+	opt := &KindHandlerOpt{
 		Kind:      header.Kind,
 		Namespace: namespace,
 		Name:      header.Metadata.Name,
 		YAML:      subYamlBytes,
 		Tags:      header.Metadata.Tags,
-	})
+	}
+	if createExecutorFunc, found := kindHandlers[header.Kind]; found {
+		return createExecutorFunc(opt)
+	}
+	if createExecutorFunc, found := kindHandlers[OtherKinds]; found {
+		if exe, err = createExecutorFunc(opt); exe != nil || err != nil {
+			return exe, err
+		}
+	}
+	util.PrintNotify(fmt.Sprintf("Could not handle kind %s. Skipping document\n", header.Kind))
+	return nil, nil
 }
 
+// OtherKinds is the key of the kind handler of the kinds without a handler of their own.
+// The handler returns no executor for the kinds it cannot handle either
+const OtherKinds config.Kind = "*"
+
 type KindHandlerOpt struct {
 	Kind      config.Kind
 	Namespace string
@@ -84,13 +92,70 @@ type KindHandlerOpt struct {
 	Tags      *[]string
 }
 
-func GetExecutorsFromYAML(inputFile, namespace string, kindHandlers map[config.Kind]func(*KindHandlerOpt) (Executor, error)) (executorsMap map[config.Kind][]Executor, err error) {
-	yamlFile, err := ioutil.ReadFile(inputFile)
+// GetExecutorsFromYAML generates the executors of the YAML documents of all input files, see ReadInputs.
+// The documents must belong to the namespace
+func GetExecutorsFromYAML(inputOpt *InputOptions, namespace string, kindHandlers map[config.Kind]func(*KindHandlerOpt) (Executor, error)) (executorsMap map[config.Kind][]Executor, err error) {
+	nsExecutors, err := getExecutorsFromYAML(inputOpt, namespace, false, kindHandlers)
+	if err != nil {
+		return nil, err
+	}
+	return nsExecutors[0].Executors, nil
+}
+
+// NamespaceExecutors are the executors of the documents of a namespace, per kind
+type NamespaceExecutors struct {
+	Namespace string
+	Executors map[config.Kind][]Executor
+}
+
+// GetExecutorsPerNamespaceFromYAML generates the executors of the YAML documents of all input files, see ReadInputs.
+// Each document belongs to the namespace of its metadata, or to the namespace if it has none.
+// The executors are grouped by namespace, in the order of the first document of each namespace
+func GetExecutorsPerNamespaceFromYAML(inputOpt *InputOptions, namespace string, kindHandlers map[config.Kind]func(*KindHandlerOpt) (Executor, error)) ([]NamespaceExecutors, error) {
+	return getExecutorsFromYAML(inputOpt, namespace, true, kindHandlers)
+}
+
+func getExecutorsFromYAML(inputOpt *InputOptions, namespace string, anyNamespace bool, kindHandlers map[config.Kind]func(*KindHandlerOpt) (Executor, error)) (nsExecutors []NamespaceExecutors, err error) {
+	inputs, err := ReadInputs(inputOpt)
 	if err != nil {
 		return
 	}
 
-	r := bytes.NewReader(yamlFile)
+	// Generate all executors
+	empty := true
+	for _, input := range inputs {
+		count, err := addExecutorsFromYAML(&nsExecutors, input, namespace, anyNamespace, kindHandlers)
+		if err != nil {
+			return nil, err
+		}
+		if count > 0 {
+			empty = false
+		}
+	}
+
+	if empty {
+		return nil, util.NewInputError("Could not decode any valid resources from input YAML files")
+	}
+
+	return nsExecutors, nil
+}
+
+// getNamespaceExecutors returns the executors of the namespace, adding the namespace if it is missing
+func getNamespaceExecutors(nsExecutors *[]NamespaceExecutors, namespace string) map[config.Kind][]Executor {
+	for _, nsExecutor := range *nsExecutors {
+		if nsExecutor.Namespace == namespace {
+			return nsExecutor.Executors
+		}
+	}
+	executorsMap := make(map[config.Kind][]Executor)
+	*nsExecutors = append(*nsExecutors, NamespaceExecutors{Namespace: namespace, Executors: executorsMap})
+	return executorsMap
+}
+
+// addExecutorsFromYAML adds the executors of the documents of an input file to those of their namespace and returns how many were added.
+// The documents belong to the namespace unless anyNamespace is set and they declare their own
+func addExecutorsFromYAML(nsExecutors *[]NamespaceExecutors, input Input, namespace string, anyNamespace bool, kindHandlers map[config.Kind]func(*KindHandlerOpt) (Executor, error)) (count int, err error) {
+	r := bytes.NewReader(input.Data)
 	dec := yaml.NewDecoder(r)
 	dec.SetStrict(true)
 
@@ -101,17 +166,19 @@ func GetExecutorsFromYAML(inputFile, namespace string, kindHandlers map[config.K
 		Metadata: config.HeaderMetadata{},
 	}
 
-	// Generate all executors
-	empty := true
-	executorsMap = make(map[config.Kind][]Executor)
 	decodeErr := dec.Decode(&header)
 	for decodeErr == nil {
-		exe, err := generateExecutor(&header, namespace, kindHandlers)
+		docNamespace := namespace
+		if anyNamespace && header.Metadata.Namespace != "" {
+			docNamespace = header.Metadata.Namespace
+		}
+		exe, err := generateExecutor(&header, docNamespace, kindHandlers)
 		if err != nil {
-			return nil, err
+			return count, err
 		}
 		if exe != nil {
-			empty = false
+			count++
+			executorsMap := getNamespaceExecutors(nsExecutors, docNamespace)
 			executorsMap[header.Kind] = append(executorsMap[header.Kind], exe)
 		}
 
@@ -124,12 +191,7 @@ func GetExecutorsFromYAML(inputFile, namespace string, kindHandlers map[config.K
 		decodeErr = dec.Decode(&header)
 	}
 	if decodeErr != io.EOF && decodeErr != nil {
-		return nil, decodeErr
+		return count, util.NewUnmarshalError(fmt.Sprintf("Failed to decode %s: %s", input.Name, decodeErr.Error()))
 	}
-
-	if empty {
-		err = util.NewInputError("Could not decode any valid resources from input YAML file")
-	}
-
-	return executorsMap, err
+	return count, nil
 }
@@ -0,0 +1,132 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+)
+
+func TestGetExecutorsPerNamespaceFromYAML(t *testing.T) {
+	dir := t.TempDir()
+	config.Init(dir)
+	if err := config.AddNamespace("prod", ""); err != nil {
+		t.Fatal(err)
+	}
+	file := filepath.Join(dir, "ecn.yaml")
+	docs := `apiVersion: iofog.org/v3
+kind: Volume
+metadata:
+  name: prod-volume
+  namespace: prod
+spec: {}
+---
+apiVersion: iofog.org/v3
+kind: Volume
+metadata:
+  name: volume
+spec: {}
+---
+apiVersion: iofog.org/v3
+kind: Route
+metadata:
+  name: app/route
+  namespace: default
+spec: {}
+`
+	if err := ioutil.WriteFile(file, []byte(docs), 0644); err != nil {
+		t.Fatal(err)
+	}
+	kindHandlers := map[config.Kind]func(*KindHandlerOpt) (Executor, error){}
+	for _, kind := range []config.Kind{config.VolumeKind, config.RouteKind} {
+		kindHandlers[kind] = func(opt *KindHandlerOpt) (Executor, error) {
+			return NewEmptyExecutor(opt.Namespace + ":" + opt.Name), nil
+		}
+	}
+	inputOpt := &InputOptions{Files: []string{file}}
+
+	nsExecutors, err := GetExecutorsPerNamespaceFromYAML(inputOpt, "default", kindHandlers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := []string{}
+	for _, nsExecutor := range nsExecutors {
+		names := []string{}
+		for _, kind := range []config.Kind{config.VolumeKind, config.RouteKind} {
+			for _, exe := range nsExecutor.Executors[kind] {
+				names = append(names, exe.GetName())
+			}
+		}
+		got = append(got, nsExecutor.Namespace+"="+strings.Join(names, ","))
+	}
+	if expected := "prod=prod:prod-volume default=default:volume,default:app/route"; strings.Join(got, " ") != expected {
+		t.Errorf("Expected %s, got %s", expected, strings.Join(got, " "))
+	}
+
+	// Documents of other namespaces are rejected unless executors are grouped by namespace
+	if _, err := GetExecutorsFromYAML(inputOpt, "default", kindHandlers); err == nil {
+		t.Error("Expected the document of namespace prod to be rejected")
+	}
+}
+
+func TestGetExecutorsFromYAMLOtherKinds(t *testing.T) {
+	dir := t.TempDir()
+	config.Init(dir)
+	file := filepath.Join(dir, "widgets.yaml")
+	docs := `apiVersion: iofog.org/v3
+kind: Volume
+metadata:
+  name: volume
+spec: {}
+---
+apiVersion: iofog.org/v3
+kind: Widget
+metadata:
+  name: widget
+spec: {}
+---
+apiVersion: iofog.org/v3
+kind: Gadget
+metadata:
+  name: gadget
+spec: {}
+`
+	if err := ioutil.WriteFile(file, []byte(docs), 0644); err != nil {
+		t.Fatal(err)
+	}
+	kindHandlers := map[config.Kind]func(*KindHandlerOpt) (Executor, error){
+		config.VolumeKind: func(opt *KindHandlerOpt) (Executor, error) {
+			return NewEmptyExecutor(opt.Name), nil
+		},
+		// Only handles Widgets
+		OtherKinds: func(opt *KindHandlerOpt) (Executor, error) {
+			if opt.Kind != "Widget" {
+				return nil, nil
+			}
+			return NewEmptyExecutor(opt.Name), nil
+		},
+	}
+
+	executorsMap, err := GetExecutorsFromYAML(&InputOptions{Files: []string{file}}, "default", kindHandlers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(executorsMap) != 2 || len(executorsMap[config.VolumeKind]) != 1 || len(executorsMap["Widget"]) != 1 {
+		t.Errorf("Expected one Volume and one Widget executor, got %v", executorsMap)
+	}
+}
@@ -0,0 +1,133 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// Matches $${ escapes and ${key} or ${key:-default} references
+var referenceRegex = regexp.MustCompile(`\$\$\{|\$\{([A-Za-z_][A-Za-z0-9_.-]*)(:-([^}]*))?\}`)
+
+// Values are substituted to the ${key} references of the YAML files before decoding them
+type Values struct {
+	values map[string]string
+	// env is set to fall back on the environment variables for the keys without value
+	env bool
+}
+
+// GetValues merges the values of the YAML files, then the key=value pairs. Later values override earlier ones.
+// The keys of nested maps are joined with dots. It returns nil if no values nor env fallback are provided,
+// so the YAML files are not rendered and their ${key} references are kept as they are
+func GetValues(valuesFiles, set []string, env bool) (*Values, error) {
+	if len(valuesFiles) == 0 && len(set) == 0 && !env {
+		return nil, nil
+	}
+	values := &Values{
+		values: make(map[string]string),
+		env:    env,
+	}
+	for _, filename := range valuesFiles {
+		data, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, err
+		}
+		fileValues := make(map[string]*valueNode)
+		if err := yaml.Unmarshal(data, &fileValues); err != nil {
+			return nil, util.NewUnmarshalError(fmt.Sprintf("Failed to decode values file %s: %s", filename, err.Error()))
+		}
+		values.add("", fileValues)
+	}
+	for _, pair := range set {
+		keyValue := strings.SplitN(pair, "=", 2)
+		if len(keyValue) != 2 || keyValue[0] == "" {
+			return nil, util.NewInputError(fmt.Sprintf("Invalid value %s, expected key=value", pair))
+		}
+		values.values[keyValue[0]] = keyValue[1]
+	}
+	return values, nil
+}
+
+// valueNode is a scalar or a map of a values file. Scalars are decoded as strings to keep their text, e.g. 1.0
+type valueNode struct {
+	scalar   string
+	children map[string]*valueNode
+}
+
+func (node *valueNode) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal(&node.scalar); err == nil {
+		return nil
+	}
+	if err := unmarshal(&node.children); err != nil {
+		return util.NewInputError("Only maps and scalars are supported in values files")
+	}
+	return nil
+}
+
+func (values *Values) add(prefix string, nodes map[string]*valueNode) {
+	for key, node := range nodes {
+		switch {
+		case node == nil:
+			values.values[prefix+key] = ""
+		case node.children != nil:
+			values.add(prefix+key+".", node.children)
+		default:
+			values.values[prefix+key] = node.scalar
+		}
+	}
+}
+
+// Render substitutes the ${key} references of the data with the values, falling back on the environment variables if enabled.
+// ${key:-default} uses the default when the key is not found, and $${ is rendered as ${. Nil values return the data unchanged
+func (values *Values) Render(name string, data []byte) ([]byte, error) {
+	if values == nil {
+		return data, nil
+	}
+	var rendered strings.Builder
+	text := string(data)
+	last := 0
+	for _, match := range referenceRegex.FindAllStringSubmatchIndex(text, -1) {
+		rendered.WriteString(text[last:match[0]])
+		last = match[1]
+		if text[match[0]:match[1]] == "$${" {
+			rendered.WriteString("${")
+			continue
+		}
+		key := text[match[2]:match[3]]
+		value, found := values.values[key]
+		if !found && values.env {
+			value, found = os.LookupEnv(key)
+		}
+		if !found && match[4] != -1 {
+			value, found = text[match[6]:match[7]], true
+		}
+		if !found {
+			line := strings.Count(text[:match[0]], "\n") + 1
+			if values.env {
+				return nil, util.NewInputError(fmt.Sprintf("%s:%d: No value or environment variable found for ${%s}", name, line, key))
+			}
+			return nil, util.NewInputError(fmt.Sprintf("%s:%d: No value found for ${%s}", name, line, key))
+		}
+		rendered.WriteString(value)
+	}
+	rendered.WriteString(text[last:])
+	return []byte(rendered.String()), nil
+}
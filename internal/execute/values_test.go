@@ -0,0 +1,89 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package execute
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRender(t *testing.T) {
+	valuesFile := filepath.Join(t.TempDir(), "values.yaml")
+	if err := ioutil.WriteFile(valuesFile, []byte("image:\n  tag: 1.0\n  name: app\n  version: 1.10\nagent: agent-1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	values, err := GetValues([]string{valuesFile}, []string{"image.tag=2.0"}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	os.Setenv("IOFOGCTL_TEST_ENV", "dev")
+	defer os.Unsetenv("IOFOGCTL_TEST_ENV")
+
+	input := "image: ${image.name}:${image.tag}\nversion: ${image.version}\nagent: ${agent}\nenv: ${IOFOGCTL_TEST_ENV}\nport: ${port:-80}\nscript: echo $${HOME} $HOME\n"
+	expected := "image: app:2.0\nversion: 1.10\nagent: agent-1\nenv: dev\nport: 80\nscript: echo ${HOME} $HOME\n"
+	rendered, err := values.Render("app.yaml", []byte(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(rendered) != expected {
+		t.Errorf("Unexpected rendering:\n%s", string(rendered))
+	}
+
+	_, err = values.Render("app.yaml", []byte("name: app\nimage: ${missing}\n"))
+	if err == nil || !strings.Contains(err.Error(), "app.yaml:2: No value or environment variable found for ${missing}") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := GetValues(nil, []string{"novalue"}, false); err == nil {
+		t.Error("Expected error for value without key")
+	}
+
+	// Environment variables are only substituted with the env fallback
+	values, err = GetValues(nil, []string{"image.tag=2.0"}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = values.Render("app.yaml", []byte("env: ${IOFOGCTL_TEST_ENV}\n"))
+	if err == nil || !strings.Contains(err.Error(), "app.yaml:1: No value found for ${IOFOGCTL_TEST_ENV}") {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestReadInputsWithoutValues(t *testing.T) {
+	manifest := `apiVersion: iofog.org/v3
+kind: Microservice
+metadata:
+  name: msvc
+spec:
+  container:
+    env:
+    - key: DATA_DIR
+      value: ${HOME}/data
+    - key: PORT
+      value: ${PORT:-80}
+`
+	filename := filepath.Join(t.TempDir(), "msvc.yaml")
+	if err := ioutil.WriteFile(filename, []byte(manifest), 0644); err != nil {
+		t.Fatal(err)
+	}
+	inputs, err := ReadInputs(&InputOptions{Files: []string{filename}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inputs) != 1 || string(inputs[0].Data) != manifest {
+		t.Errorf("Expected manifest to be unchanged, got %v", inputs)
+	}
+}
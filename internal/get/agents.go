@@ -14,6 +14,7 @@
 package get
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -40,7 +41,7 @@ func (exe *agentExecutor) GetName() string {
 	return ""
 }
 
-func (exe *agentExecutor) Execute() error {
+func (exe *agentExecutor) Execute(ctx context.Context) error {
 	if exe.showDetached {
 		printDetached()
 		table, err := generateDetachedAgentOutput()
@@ -14,6 +14,8 @@
 package get
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 )
@@ -50,7 +52,7 @@ func (exe *allExecutor) GetName() string {
 	return ""
 }
 
-func (exe *allExecutor) Execute() error {
+func (exe *allExecutor) Execute(ctx context.Context) error {
 	// Check namespace exists
 	_, err := config.GetNamespace(exe.namespace)
 	if err != nil {
@@ -14,6 +14,7 @@
 package get
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
@@ -40,7 +41,7 @@ func (exe *applicationExecutor) GetName() string {
 	return ""
 }
 
-func (exe *applicationExecutor) Execute() error {
+func (exe *applicationExecutor) Execute(ctx context.Context) error {
 	// Fetch data
 	if err := exe.init(); err != nil {
 		return err
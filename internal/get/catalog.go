@@ -14,6 +14,7 @@
 package get
 
 import (
+	"context"
 	"strconv"
 
 	apps "github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/apps"
@@ -31,7 +32,7 @@ func newCatalogExecutor(namespace string) *catalogExecutor {
 	return a
 }
 
-func (exe *catalogExecutor) Execute() error {
+func (exe *catalogExecutor) Execute(ctx context.Context) error {
 	printNamespace(exe.namespace)
 	if err := generateCatalogOutput(exe.namespace); err != nil {
 		return err
@@ -14,6 +14,7 @@
 package get
 
 import (
+	"context"
 	"time"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
@@ -38,7 +39,7 @@ func (exe *controllerExecutor) GetName() string {
 	return ""
 }
 
-func (exe *controllerExecutor) Execute() error {
+func (exe *controllerExecutor) Execute(ctx context.Context) error {
 	table, err := generateControllerOutput(exe.namespace)
 	if err != nil {
 		return err
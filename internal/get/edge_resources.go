@@ -14,6 +14,7 @@
 package get
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
@@ -36,7 +37,7 @@ func (exe *edgeResourceExecutor) GetName() string {
 	return ""
 }
 
-func (exe *edgeResourceExecutor) Execute() error {
+func (exe *edgeResourceExecutor) Execute(ctx context.Context) error {
 	printNamespace(exe.namespace)
 	table, err := generateEdgeResourceOutput(exe.namespace)
 	if err != nil {
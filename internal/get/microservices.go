@@ -14,6 +14,7 @@
 package get
 
 import (
+	"context"
 	"fmt"
 	"math"
 	"strings"
@@ -69,7 +70,7 @@ func (exe *microserviceExecutor) GetName() string {
 	return ""
 }
 
-func (exe *microserviceExecutor) Execute() error {
+func (exe *microserviceExecutor) Execute(ctx context.Context) error {
 	// Fetch data
 	if err := exe.init(); err != nil {
 		return err
@@ -14,6 +14,8 @@
 package get
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
@@ -31,7 +33,7 @@ func (exe *namespaceExecutor) GetName() string {
 	return ""
 }
 
-func (exe *namespaceExecutor) Execute() error {
+func (exe *namespaceExecutor) Execute(ctx context.Context) error {
 	namespacesNames := config.GetNamespaces()
 	namespaces := make([]*rsc.Namespace, len(namespacesNames))
 	for idx, n := range namespacesNames {
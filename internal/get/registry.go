@@ -14,6 +14,7 @@
 package get
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
@@ -30,7 +31,7 @@ func newRegistryExecutor(namespace string) *registryExecutor {
 	return a
 }
 
-func (exe *registryExecutor) Execute() error {
+func (exe *registryExecutor) Execute(ctx context.Context) error {
 	printNamespace(exe.namespace)
 	if err := generateRegistryOutput(exe.namespace); err != nil {
 		return err
@@ -14,6 +14,8 @@
 package get
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
@@ -34,7 +36,7 @@ func (exe *routeExecutor) GetName() string {
 	return ""
 }
 
-func (exe *routeExecutor) Execute() error {
+func (exe *routeExecutor) Execute(ctx context.Context) error {
 	printNamespace(exe.namespace)
 	table, err := generateRouteOutput(exe.namespace)
 	if err != nil {
@@ -14,6 +14,7 @@
 package get
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
@@ -36,7 +37,7 @@ func (exe *applicationTemplateExecutor) GetName() string {
 	return ""
 }
 
-func (exe *applicationTemplateExecutor) Execute() error {
+func (exe *applicationTemplateExecutor) Execute(ctx context.Context) error {
 	// Fetch data
 	if err := exe.init(); err != nil {
 		return err
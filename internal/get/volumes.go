@@ -14,6 +14,8 @@
 package get
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 )
 
@@ -31,7 +33,7 @@ func (exe *volumeExecutor) GetName() string {
 	return ""
 }
 
-func (exe *volumeExecutor) Execute() error {
+func (exe *volumeExecutor) Execute(ctx context.Context) error {
 	printNamespace(exe.namespace)
 	table, err := generateVolumeOutput(exe.namespace)
 	if err != nil {
@@ -0,0 +1,60 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package history
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+)
+
+// hashLength is the number of characters of the hashes printed
+const hashLength = 12
+
+type Options struct {
+	Namespace string
+	Name      string
+}
+
+// Execute prints the revisions of an Application, oldest first
+func Execute(opt *Options) error {
+	if _, err := config.GetNamespace(opt.Namespace); err != nil {
+		return err
+	}
+	revisions, err := config.GetRevisions(opt.Namespace, config.ApplicationKind, opt.Name)
+	if err != nil {
+		return err
+	}
+	if len(revisions) == 0 {
+		return util.NewNotFoundError(fmt.Sprintf("Could not find any revision of Application %s. Revisions are recorded when an Application is deployed", opt.Name))
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 16, 8, 1, '\t', 0)
+	if _, err := fmt.Fprintf(writer, "REVISION\tTIMESTAMP\tHASH\t\n"); err != nil {
+		return err
+	}
+	for _, revision := range revisions {
+		hash := revision.Hash
+		if len(hash) > hashLength {
+			hash = hash[:hashLength]
+		}
+		if _, err := fmt.Fprintf(writer, "%d\t%s\t%s\t\n", revision.Number, revision.Timestamp, hash); err != nil {
+			return err
+		}
+	}
+	return writer.Flush()
+}
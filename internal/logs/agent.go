@@ -14,13 +14,13 @@
 package logs
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog/install"
-	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
 type agentExecutor struct {
@@ -39,7 +39,7 @@ func (exe *agentExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *agentExecutor) Execute() error {
+func (exe *agentExecutor) Execute(ctx context.Context) error {
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
 		return err
@@ -61,6 +61,7 @@ func (exe *agentExecutor) Execute() error {
 		if err != nil {
 			return err
 		}
+		lc.SetContext(ctx)
 		containerName := install.GetLocalContainerName("agent", false)
 		stdout, stderr, err := lc.GetLogsByName(containerName)
 		if err != nil {
@@ -75,11 +76,11 @@ func (exe *agentExecutor) Execute() error {
 		if err := agent.ValidateSSH(); err != nil {
 			return err
 		}
-		ssh, err := util.NewSecureShellClient(agent.SSH.User, agent.Host, agent.SSH.KeyFile)
+		ssh, err := agent.SSH.NewClient(agent.Host)
 		if err != nil {
 			return err
 		}
-		ssh.SetPort(agent.SSH.Port)
+		ssh.SetContext(ctx)
 		err = ssh.Connect()
 		if err != nil {
 			return err
@@ -14,6 +14,7 @@
 package logs
 
 import (
+	"context"
 	"fmt"
 
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
@@ -38,7 +39,7 @@ func (exe *kubernetesControllerExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *kubernetesControllerExecutor) Execute() error {
+func (exe *kubernetesControllerExecutor) Execute(ctx context.Context) error {
 	if err := exe.controlPlane.ValidateKubeConfig(); err != nil {
 		return err
 	}
@@ -14,6 +14,8 @@
 package logs
 
 import (
+	"context"
+
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog/install"
 )
@@ -36,11 +38,12 @@ func (exe *localControllerExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *localControllerExecutor) Execute() error {
+func (exe *localControllerExecutor) Execute(ctx context.Context) error {
 	lc, err := install.NewLocalContainerClient()
 	if err != nil {
 		return err
 	}
+	lc.SetContext(ctx)
 	containerName := install.GetLocalContainerName("controller", false)
 	stdout, stderr, err := lc.GetLogsByName(containerName)
 	if err != nil {
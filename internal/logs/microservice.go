@@ -15,6 +15,7 @@ package logs
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"strings"
 
@@ -42,7 +43,7 @@ func (ms *remoteMicroserviceExecutor) GetName() string {
 	return ms.name
 }
 
-func (ms *remoteMicroserviceExecutor) Execute() error {
+func (ms *remoteMicroserviceExecutor) Execute(ctx context.Context) error {
 	// Get image name of the microservice and details of the Agent its deployed on
 	baseAgent, msvc, err := getAgentAndMicroservice(ms.namespace, ms.name)
 	if err != nil {
@@ -59,6 +60,7 @@ func (ms *remoteMicroserviceExecutor) Execute() error {
 		if err != nil {
 			return err
 		}
+		lc.SetContext(ctx)
 		containerName := "iofog_" + msvc.UUID
 		stdout, stderr, err := lc.GetLogsByName(containerName)
 		if err != nil {
@@ -75,25 +77,31 @@ func (ms *remoteMicroserviceExecutor) Execute() error {
 		}
 
 		// SSH into the Agent and get the logs
-		ssh, err := util.NewSecureShellClient(agent.SSH.User, agent.Host, agent.SSH.KeyFile)
+		ssh, err := agent.SSH.NewClient(agent.Host)
 		if err != nil {
 			return err
 		}
-		ssh.SetPort(agent.SSH.Port)
+		ssh.SetContext(ctx)
 		if err := ssh.Connect(); err != nil {
 			return err
 		}
 
+		// Run the Docker commands as root if the user cannot access Docker
+		dockerAccess, err := hasDockerAccess(ssh)
+		if err != nil {
+			return err
+		}
+
 		// Notify the user of the containers that are up
 		containerName := "iofog_" + msvc.UUID
-		out, err := ms.runDockerCommand(fmt.Sprintf("docker ps | grep %s", containerName), ssh)
+		out, err := ms.runDockerCommand(fmt.Sprintf("docker ps | grep %s", containerName), ssh, dockerAccess)
 		if err != nil {
 			return err
 		}
 
 		// Execute the command
 		cmd := fmt.Sprintf("docker ps | grep %s | awk 'FNR == 1 {print $1}' | xargs docker logs", containerName)
-		out, err = ms.runDockerCommand(cmd, ssh)
+		out, err = ms.runDockerCommand(cmd, ssh, dockerAccess)
 		if err != nil {
 			return err
 		}
@@ -105,21 +113,21 @@ func (ms *remoteMicroserviceExecutor) Execute() error {
 	return nil
 }
 
-func (ms *remoteMicroserviceExecutor) runDockerCommand(cmd string, ssh *util.SecureShellClient) (stdout bytes.Buffer, err error) {
-	stdout, err = ssh.Run(cmd)
+// hasDockerAccess returns whether the user of the SSH client can run Docker commands, according to the exit status of docker info
+func hasDockerAccess(ssh *util.SecureShellClient) (bool, error) {
+	out, err := ssh.Run("docker info >/dev/null 2>&1 && echo yes || echo no")
 	if err != nil {
-		if !strings.Contains(strings.ToLower(err.Error()), "permission denied") {
-			return
-		}
-		// Retry with sudo
-		cmd = strings.Replace(cmd, "docker", "sudo docker", -1)
+		return false, err
+	}
+	return strings.TrimSpace(out.String()) == "yes", nil
+}
 
-		stdout, err = ssh.Run(cmd)
-		if err != nil {
-			return
-		}
+// runDockerCommand runs the command, with its whole pipeline as root if the user cannot access Docker
+func (ms *remoteMicroserviceExecutor) runDockerCommand(cmd string, ssh *util.SecureShellClient, dockerAccess bool) (stdout bytes.Buffer, err error) {
+	if dockerAccess {
+		return ssh.Run(cmd)
 	}
-	return
+	return ssh.RunPrivileged(cmd)
 }
 
 func getAgentAndMicroservice(namespace, msvcFQName string) (agent rsc.Agent, msvc client.MicroserviceInfo, err error) {
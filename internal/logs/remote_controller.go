@@ -14,6 +14,7 @@
 package logs
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
@@ -39,7 +40,7 @@ func (exe *remoteControllerExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *remoteControllerExecutor) Execute() error {
+func (exe *remoteControllerExecutor) Execute(ctx context.Context) error {
 	// Get controller config
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
@@ -63,11 +64,11 @@ func (exe *remoteControllerExecutor) Execute() error {
 	if err := ctrl.ValidateSSH(); err != nil {
 		return err
 	}
-	ssh, err := util.NewSecureShellClient(ctrl.SSH.User, ctrl.Host, ctrl.SSH.KeyFile)
+	ssh, err := ctrl.SSH.NewClient(ctrl.Host)
 	if err != nil {
 		return err
 	}
-	ssh.SetPort(ctrl.SSH.Port)
+	ssh.SetContext(ctx)
 	if err := ssh.Connect(); err != nil {
 		return err
 	}
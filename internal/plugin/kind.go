@@ -0,0 +1,196 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"gopkg.in/yaml.v3"
+)
+
+// KindPrefix is the prefix of the executables handling the documents of a kind, e.g. iofogctl-kind-widget handles the Widget kind
+const KindPrefix = Prefix + "kind-"
+
+// Verb is the first argument of a kind plugin
+type Verb string
+
+const (
+	DeployVerb Verb = "deploy"
+	DeleteVerb Verb = "delete"
+	// PlanVerb expects a PlanResponse without modifying anything
+	PlanVerb Verb = "plan"
+)
+
+// Request is written to the standard input of a kind plugin
+type Request struct {
+	Verb      Verb          `json:"verb"`
+	Document  config.Header `json:"document"`
+	Namespace Namespace     `json:"namespace"`
+}
+
+// Namespace holds the details required to connect to the Controller of the Namespace of a document.
+// Controller is nil when the Namespace has no Control Plane
+type Namespace struct {
+	Name       string      `json:"name"`
+	Controller *Controller `json:"controller,omitempty"`
+}
+
+type Controller struct {
+	Endpoint    string `json:"endpoint"`
+	Email       string `json:"email"`
+	Password    string `json:"password"`
+	AccessToken string `json:"accessToken"`
+}
+
+// PlanResponse is read from the standard output of a kind plugin run with the plan verb
+type PlanResponse struct {
+	Action execute.Action `json:"action"`
+	Detail string         `json:"detail"`
+}
+
+// GetKindPath returns the path of the plugin handling the kind
+func GetKindPath(kind config.Kind) (string, error) {
+	return exec.LookPath(KindPrefix + strings.ToLower(string(kind)))
+}
+
+// NewKindHandler returns a kind handler running the plugin of the kind of each document with the verb.
+// The documents of kinds without a plugin have no executor
+func NewKindHandler(verb Verb) func(*execute.KindHandlerOpt) (execute.Executor, error) {
+	return func(opt *execute.KindHandlerOpt) (execute.Executor, error) {
+		path, err := GetKindPath(opt.Kind)
+		if err != nil {
+			return nil, nil
+		}
+		var spec interface{}
+		if err := yaml.Unmarshal(opt.YAML, &spec); err != nil {
+			return nil, util.NewUnmarshalError(fmt.Sprintf("Could not decode the spec of %s %s: %s", opt.Kind, opt.Name, err.Error()))
+		}
+		return &kindExecutor{
+			verb: verb,
+			path: path,
+			document: config.Header{
+				APIVersion: config.LatestAPIVersion,
+				Kind:       opt.Kind,
+				Metadata: config.HeaderMetadata{
+					Name:      opt.Name,
+					Namespace: opt.Namespace,
+					Tags:      opt.Tags,
+				},
+				Spec: spec,
+			},
+		}, nil
+	}
+}
+
+type kindExecutor struct {
+	verb     Verb
+	path     string
+	document config.Header
+}
+
+func (exe *kindExecutor) GetName() string {
+	return exe.document.Metadata.Name
+}
+
+func (exe *kindExecutor) Execute(ctx context.Context) error {
+	_, err := exe.run(ctx, exe.verb)
+	return err
+}
+
+func (exe *kindExecutor) Plan() ([]execute.Change, error) {
+	output, err := exe.run(context.Background(), PlanVerb)
+	if err != nil {
+		return nil, err
+	}
+	var response PlanResponse
+	if err := json.Unmarshal(output, &response); err != nil {
+		return nil, util.NewUnmarshalError(fmt.Sprintf("Could not decode the plan of %s %s returned by %s: %s", exe.document.Kind, exe.GetName(), filepath.Base(exe.path), err.Error()))
+	}
+	switch response.Action {
+	case execute.CreateAction, execute.UpdateAction, execute.NoOpAction, execute.DeleteAction:
+	default:
+		return nil, util.NewError(fmt.Sprintf("Unknown action %s in the plan of %s %s returned by %s", response.Action, exe.document.Kind, exe.GetName(), filepath.Base(exe.path)))
+	}
+	return []execute.Change{{
+		Kind:   exe.document.Kind,
+		Name:   exe.GetName(),
+		Action: response.Action,
+		Detail: response.Detail,
+	}}, nil
+}
+
+// run runs the plugin with the verb and the request on its standard input, and returns its standard output
+func (exe *kindExecutor) run(ctx context.Context, verb Verb) ([]byte, error) {
+	namespace, err := getNamespace(exe.document.Metadata.Namespace)
+	if err != nil {
+		return nil, err
+	}
+	input, err := json.Marshal(Request{Verb: verb, Document: exe.document, Namespace: namespace})
+	if err != nil {
+		return nil, err
+	}
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, exe.path, string(verb))
+	cmd.Stdin = bytes.NewReader(input)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			msg = err.Error()
+		}
+		return nil, util.NewError(fmt.Sprintf("Plugin %s failed to %s %s %s: %s", filepath.Base(exe.path), verb, exe.document.Kind, exe.GetName(), msg))
+	}
+	return stdout.Bytes(), nil
+}
+
+func getNamespace(name string) (namespace Namespace, err error) {
+	namespace.Name = name
+	ns, err := config.GetNamespace(name)
+	if err != nil {
+		return
+	}
+	controlPlane, err := ns.GetControlPlane()
+	if err != nil {
+		// Nothing to connect to yet
+		return namespace, nil
+	}
+	endpoint, err := controlPlane.GetEndpoint()
+	if err != nil {
+		return
+	}
+	clt, err := clientutil.NewControllerClient(name)
+	if err != nil {
+		return
+	}
+	user := controlPlane.GetUser()
+	namespace.Controller = &Controller{
+		Endpoint:    endpoint,
+		Email:       user.Email,
+		Password:    user.GetRawPassword(),
+		AccessToken: clt.GetAccessToken(),
+	}
+	return namespace, nil
+}
@@ -0,0 +1,89 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package plugin
+
+import (
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Prefix is the prefix of the executables run as iofogctl commands, e.g. iofogctl-foo is run as iofogctl foo
+const Prefix = "iofogctl-"
+
+// Plugin is an executable of a directory of PATH
+type Plugin struct {
+	// Name is the command of the plugin, its filename without the prefix
+	Name string
+	Path string
+}
+
+// List returns the command plugins of the directories of PATH.
+// A plugin shadows the plugins with the same name in the following directories. Kind plugins are not listed
+func List() []Plugin {
+	plugins := []Plugin{}
+	found := make(map[string]bool)
+	for _, dir := range filepath.SplitList(os.Getenv("PATH")) {
+		if dir == "" {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			filename := entry.Name()
+			if !strings.HasPrefix(filename, Prefix) || strings.HasPrefix(filename, KindPrefix) {
+				continue
+			}
+			path := filepath.Join(dir, filename)
+			if !isExecutable(path) {
+				continue
+			}
+			name := strings.TrimPrefix(filename, Prefix)
+			if runtime.GOOS == "windows" {
+				name = strings.TrimSuffix(name, filepath.Ext(name))
+			}
+			if name == "" || found[name] {
+				continue
+			}
+			found[name] = true
+			plugins = append(plugins, Plugin{Name: name, Path: path})
+		}
+	}
+	return plugins
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || !info.Mode().IsRegular() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		return strings.EqualFold(filepath.Ext(path), ".exe")
+	}
+	return info.Mode().Perm()&0111 != 0
+}
+
+// Run runs the plugin with the arguments, attached to the standard streams of the process
+func Run(ctx context.Context, plugin Plugin, args []string) error {
+	cmd := exec.CommandContext(ctx, plugin.Path, args...)
+	cmd.Stdin = os.Stdin
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
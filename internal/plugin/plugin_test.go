@@ -0,0 +1,137 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package plugin
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+)
+
+func writeScript(t *testing.T, path, script string, perm os.FileMode) {
+	if err := ioutil.WriteFile(path, []byte("#!/bin/sh\n"+script), perm); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestList(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Plugins are shell scripts")
+	}
+	first := t.TempDir()
+	second := t.TempDir()
+	writeScript(t, filepath.Join(first, "iofogctl-foo"), "", 0755)
+	writeScript(t, filepath.Join(first, "iofogctl-bar"), "", 0644)
+	writeScript(t, filepath.Join(first, "iofogctl-kind-widget"), "", 0755)
+	writeScript(t, filepath.Join(second, "iofogctl-foo"), "", 0755)
+	writeScript(t, filepath.Join(second, "iofogctl-baz"), "", 0755)
+	writeScript(t, filepath.Join(second, "kubectl-foo"), "", 0755)
+	t.Setenv("PATH", first+string(os.PathListSeparator)+second)
+
+	expected := []Plugin{
+		{Name: "foo", Path: filepath.Join(first, "iofogctl-foo")},
+		{Name: "baz", Path: filepath.Join(second, "iofogctl-baz")},
+	}
+	plugins := List()
+	if len(plugins) != len(expected) {
+		t.Fatalf("Expected plugins %v, got %v", expected, plugins)
+	}
+	for idx := range expected {
+		if plugins[idx] != expected[idx] {
+			t.Errorf("Expected plugin %v, got %v", expected[idx], plugins[idx])
+		}
+	}
+}
+
+func TestKindHandler(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Plugins are shell scripts")
+	}
+	dir := t.TempDir()
+	config.Init(dir)
+	requestFile := filepath.Join(dir, "request.json")
+	script := `cat > ` + requestFile + `
+if [ "$1" = plan ]; then
+  echo '{"action": "create", "detail": "New widget"}'
+fi
+`
+	writeScript(t, filepath.Join(dir, "iofogctl-kind-widget"), script, 0755)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	handler := NewKindHandler(DeployVerb)
+	exe, err := handler(&execute.KindHandlerOpt{Kind: "Gadget", Namespace: "default", Name: "gadget", YAML: []byte("{}")})
+	if err != nil || exe != nil {
+		t.Fatalf("Expected no executor for a kind without plugin, got %v and %v", exe, err)
+	}
+	exe, err = handler(&execute.KindHandlerOpt{Kind: "Widget", Namespace: "default", Name: "widget", YAML: []byte("size: 3\n")})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := exe.Execute(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	data, err := ioutil.ReadFile(requestFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var request Request
+	if err := json.Unmarshal(data, &request); err != nil {
+		t.Fatal(err)
+	}
+	if request.Verb != DeployVerb || request.Document.Kind != "Widget" || request.Document.Metadata.Name != "widget" || request.Namespace.Name != "default" {
+		t.Errorf("Unexpected request %s", data)
+	}
+	if spec, ok := request.Document.Spec.(map[string]interface{}); !ok || spec["size"] != float64(3) {
+		t.Errorf("Unexpected spec in request %s", data)
+	}
+	if request.Namespace.Controller != nil {
+		t.Errorf("Expected no Controller in a Namespace without Control Plane, got %v", request.Namespace.Controller)
+	}
+
+	changes, err := exe.(execute.PlanExecutor).Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 || changes[0] != (execute.Change{Kind: "Widget", Name: "widget", Action: execute.CreateAction, Detail: "New widget"}) {
+		t.Errorf("Unexpected changes %v", changes)
+	}
+}
+
+func TestKindHandlerFailure(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("Plugins are shell scripts")
+	}
+	dir := t.TempDir()
+	config.Init(dir)
+	writeScript(t, filepath.Join(dir, "iofogctl-kind-widget"), "echo 'widget is broken' >&2\nexit 1\n", 0755)
+	t.Setenv("PATH", dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+
+	exe, err := NewKindHandler(DeleteVerb)(&execute.KindHandlerOpt{Kind: "Widget", Namespace: "default", Name: "widget", YAML: []byte("{}")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = exe.Execute(context.Background())
+	expected := "Plugin iofogctl-kind-widget failed to delete Widget widget: widget is broken"
+	if err == nil || err.Error() != expected {
+		t.Errorf("Expected error %s, got %v", expected, err)
+	}
+}
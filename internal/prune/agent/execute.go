@@ -14,6 +14,8 @@
 package pruneagent
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
@@ -35,7 +37,7 @@ func (exe executor) GetName() string {
 	return exe.name
 }
 
-func (exe executor) Execute() error {
+func (exe executor) Execute(ctx context.Context) error {
 	util.SpinStart("Pruning Agent")
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
@@ -58,12 +60,12 @@ func (exe executor) Execute() error {
 	// Prune Agent
 	switch agent := baseAgent.(type) {
 	case *rsc.LocalAgent:
-		if err := exe.localAgentPrune(); err != nil {
+		if err := exe.localAgentPrune(ctx); err != nil {
 			return err
 		}
 	case *rsc.RemoteAgent:
 		if exe.useDetached {
-			if err := exe.remoteDetachedAgentPrune(agent); err != nil {
+			if err := exe.remoteDetachedAgentPrune(ctx, agent); err != nil {
 				return err
 			}
 		} else {
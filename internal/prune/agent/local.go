@@ -14,17 +14,19 @@
 package pruneagent
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog/install"
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
-func (exe executor) localAgentPrune() error {
+func (exe executor) localAgentPrune(ctx context.Context) error {
 	containerClient, err := install.NewLocalContainerClient()
 	if err != nil {
 		return err
 	}
+	containerClient.SetContext(ctx)
 	if _, err = containerClient.ExecuteCmd(install.GetLocalContainerName("agent", false), []string{
 		"sudo",
 		"iofog-agent",
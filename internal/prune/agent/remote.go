@@ -14,6 +14,7 @@
 package pruneagent
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -38,14 +39,18 @@ func (exe executor) remoteAgentPrune(agent rsc.Agent) error {
 	return nil
 }
 
-func (exe executor) remoteDetachedAgentPrune(agent *rsc.RemoteAgent) error {
+func (exe executor) remoteDetachedAgentPrune(ctx context.Context, agent *rsc.RemoteAgent) error {
 	if err := agent.ValidateSSH(); err != nil {
 		return err
 	}
-	sshAgent, err := install.NewRemoteAgent(agent.SSH.User, agent.Host, agent.SSH.Port, agent.SSH.KeyFile, agent.Name, agent.UUID)
+	sshAgent, err := install.NewRemoteAgent(agent.SSH.User, agent.Host, agent.SSH.Port, agent.SSH.GetAuth(), agent.Name, agent.UUID)
 	if err != nil {
 		return err
 	}
+	sshAgent.SetContext(ctx)
+	sshAgent.SetHostKey(agent.SSH.HostKey)
+	sshAgent.SetJumpHosts(agent.SSH.GetJumpHosts())
+	sshAgent.SetPrivilege(agent.SSH.GetPrivilege())
 	if err := sshAgent.Prune(); err != nil {
 		return util.NewInternalError(fmt.Sprintf("Failed to Prune Iofog resource %s. %s", agent.Name, err.Error()))
 	}
@@ -47,6 +47,9 @@ func Execute(namespace, name, newName string) error {
 	if err != nil {
 		return err
 	}
+	if err := config.RenameRevisions(namespace, config.ApplicationKind, name, newName); err != nil {
+		return err
+	}
 	config.Flush()
 	return nil
 }
@@ -0,0 +1,54 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package render
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+)
+
+type Options struct {
+	Input execute.InputOptions
+}
+
+// Execute prints the input files rendered with the values
+func Execute(opt *Options) error {
+	inputs, err := execute.ReadInputs(&opt.Input)
+	if err != nil {
+		return err
+	}
+	return write(os.Stdout, inputs)
+}
+
+func write(writer io.Writer, inputs []execute.Input) error {
+	for idx, input := range inputs {
+		if idx > 0 {
+			if _, err := fmt.Fprintln(writer, "---"); err != nil {
+				return err
+			}
+		}
+		data := string(input.Data)
+		if !strings.HasSuffix(data, "\n") {
+			data += "\n"
+		}
+		if _, err := fmt.Fprint(writer, data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
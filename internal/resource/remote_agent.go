@@ -15,7 +15,6 @@ package resource
 
 import (
 	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog/install"
-	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
 type AgentScripts struct {
@@ -80,13 +79,8 @@ func (agent *RemoteAgent) SetConfig(config *AgentConfiguration) {
 }
 
 func (agent *RemoteAgent) Sanitize() (err error) {
-	if agent.SSH.Port == 0 {
-		agent.SSH.Port = 22
-	}
-	if agent.SSH.KeyFile, err = util.FormatPath(agent.SSH.KeyFile); err != nil {
-		return
-	}
-	return
+	// The port defaults to the one of ~/.ssh/config, or 22
+	return agent.SSH.Sanitize()
 }
 
 func (agent *RemoteAgent) Clone() Agent {
@@ -114,7 +108,8 @@ func (agent *RemoteAgent) Clone() Agent {
 }
 
 func (agent *RemoteAgent) ValidateSSH() error {
-	if agent.Host == "" || agent.SSH.User == "" || agent.SSH.Port == 0 || agent.SSH.KeyFile == "" {
+	// The user, port and authentication can be read from ~/.ssh/config and the ssh-agent
+	if agent.Host == "" || agent.SSH.Validate(agent.Host) != nil {
 		return NewNoSSHConfigError("Agent")
 	}
 	return nil
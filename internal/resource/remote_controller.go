@@ -13,10 +13,6 @@
 
 package resource
 
-import (
-	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
-)
-
 type RemoteController struct {
 	ControllerConfig
 	Name     string `yaml:"name"`
@@ -43,15 +39,8 @@ func (ctrl *RemoteController) SetName(name string) {
 }
 
 func (ctrl *RemoteController) Sanitize() (err error) {
-	// Fix SSH port
-	if ctrl.Host != "" && ctrl.SSH.Port == 0 {
-		ctrl.SSH.Port = 22
-	}
-	// Format file paths
-	if ctrl.SSH.KeyFile, err = util.FormatPath(ctrl.SSH.KeyFile); err != nil {
-		return
-	}
-	return
+	// Format file paths and check keys, the port defaults to the one of ~/.ssh/config, or 22
+	return ctrl.SSH.Sanitize()
 }
 
 func (ctrl *RemoteController) Clone() Controller {
@@ -65,7 +54,8 @@ func (ctrl *RemoteController) Clone() Controller {
 }
 
 func (ctrl *RemoteController) ValidateSSH() error {
-	if ctrl.Host == "" || ctrl.SSH.User == "" || ctrl.SSH.Port == 0 || ctrl.SSH.KeyFile == "" {
+	// The user, port and authentication can be read from ~/.ssh/config and the ssh-agent
+	if ctrl.Host == "" || ctrl.SSH.Validate(ctrl.Host) != nil {
 		return NewNoSSHConfigError("Controller")
 	}
 	return nil
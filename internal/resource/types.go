@@ -16,6 +16,7 @@ package resource
 import (
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/apps"
 	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
 )
 
 type Route = apps.Route
@@ -38,6 +39,133 @@ type SSH struct {
 	User    string `yaml:"user,omitempty"`
 	Port    int    `yaml:"port,omitempty"`
 	KeyFile string `yaml:"keyFile,omitempty"`
+	// KeyPassphraseEnv is the environment variable holding the passphrase of an encrypted key file, which is prompted for otherwise
+	KeyPassphraseEnv string `yaml:"keyPassphraseEnv,omitempty"`
+	// Agent authenticates with the keys of the ssh-agent of SSH_AUTH_SOCK
+	Agent bool `yaml:"agent,omitempty"`
+	// PasswordAuth authenticates with a password, read from PasswordEnv or prompted for
+	PasswordAuth bool   `yaml:"passwordAuth,omitempty"`
+	PasswordEnv  string `yaml:"passwordEnv,omitempty"`
+	// HostKey pins the key of the host, as a public key or a SHA256 fingerprint
+	HostKey string `yaml:"hostKey,omitempty"`
+	// JumpHosts are the hosts the connection is tunnelled through, in order
+	JumpHosts []JumpHost `yaml:"jumpHosts,omitempty"`
+	// Escalation is how the commands are run as root: sudo, doas, or root when logged in as root
+	Escalation string `yaml:"escalation,omitempty"`
+	// SudoPasswordAuth answers the password prompts of sudo or doas, with the password read from SudoPasswordEnv or prompted for
+	SudoPasswordAuth bool   `yaml:"sudoPasswordAuth,omitempty"`
+	SudoPasswordEnv  string `yaml:"sudoPasswordEnv,omitempty"`
+}
+
+// GetAuth returns the authentication methods of the SSH client
+func (ssh SSH) GetAuth() util.SSHAuth {
+	return util.SSHAuth{
+		KeyFile:          ssh.KeyFile,
+		KeyPassphraseEnv: ssh.KeyPassphraseEnv,
+		Agent:            ssh.Agent,
+		PasswordAuth:     ssh.PasswordAuth,
+		PasswordEnv:      ssh.PasswordEnv,
+	}
+}
+
+// GetJumpHosts returns the jump hosts of the SSH client
+func (ssh SSH) GetJumpHosts() (jumpHosts []util.SSHJumpHost) {
+	for _, jumpHost := range ssh.JumpHosts {
+		jumpHosts = append(jumpHosts, util.SSHJumpHost{
+			User:    jumpHost.User,
+			Host:    jumpHost.Host,
+			Port:    jumpHost.Port,
+			Auth:    jumpHost.GetAuth(),
+			HostKey: jumpHost.HostKey,
+		})
+	}
+	return jumpHosts
+}
+
+// GetPrivilege returns how the SSH client runs commands as root
+func (ssh SSH) GetPrivilege() util.SSHPrivilege {
+	return util.SSHPrivilege{
+		Escalation:   util.PrivilegeEscalation(ssh.Escalation),
+		PasswordAuth: ssh.SudoPasswordAuth,
+		PasswordEnv:  ssh.SudoPasswordEnv,
+	}
+}
+
+// NewClient returns an SSH client of the host
+func (ssh SSH) NewClient(host string) (*util.SecureShellClient, error) {
+	client, err := util.NewSecureShellClient(ssh.User, host, ssh.GetAuth())
+	if err != nil {
+		return nil, err
+	}
+	client.SetPort(ssh.Port)
+	client.SetHostKey(ssh.HostKey)
+	client.SetJumpHosts(ssh.GetJumpHosts())
+	client.SetPrivilege(ssh.GetPrivilege())
+	return client, nil
+}
+
+// Validate checks the user and authentication of the SSH client of the host are provided,
+// or found in ~/.ssh/config and the ssh-agent
+func (ssh SSH) Validate(host string) error {
+	client, err := ssh.NewClient(host)
+	if err != nil {
+		return err
+	}
+	return client.CheckConfig()
+}
+
+// Sanitize formats the key files and checks the host keys of the SSH client and of its jump hosts, and its privilege escalation
+func (ssh *SSH) Sanitize() (err error) {
+	if err = ssh.GetPrivilege().Validate(); err != nil {
+		return
+	}
+	if ssh.KeyFile, err = util.FormatPath(ssh.KeyFile); err != nil {
+		return
+	}
+	if ssh.HostKey != "" {
+		if _, err = util.ParseHostKey(ssh.HostKey); err != nil {
+			return
+		}
+	}
+	for idx := range ssh.JumpHosts {
+		jumpHost := &ssh.JumpHosts[idx]
+		if jumpHost.Host == "" {
+			return util.NewInputError("Jump hosts must have a host")
+		}
+		if jumpHost.KeyFile, err = util.FormatPath(jumpHost.KeyFile); err != nil {
+			return
+		}
+		if jumpHost.HostKey != "" {
+			if _, err = util.ParseHostKey(jumpHost.HostKey); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// JumpHost is a host the SSH connection is tunnelled through, like the ProxyJump option of OpenSSH
+type JumpHost struct {
+	Host             string `yaml:"host"`
+	User             string `yaml:"user,omitempty"`
+	Port             int    `yaml:"port,omitempty"`
+	KeyFile          string `yaml:"keyFile,omitempty"`
+	KeyPassphraseEnv string `yaml:"keyPassphraseEnv,omitempty"`
+	Agent            bool   `yaml:"agent,omitempty"`
+	PasswordAuth     bool   `yaml:"passwordAuth,omitempty"`
+	PasswordEnv      string `yaml:"passwordEnv,omitempty"`
+	HostKey          string `yaml:"hostKey,omitempty"`
+}
+
+// GetAuth returns the authentication methods of the jump host
+func (jumpHost JumpHost) GetAuth() util.SSHAuth {
+	return util.SSHAuth{
+		KeyFile:          jumpHost.KeyFile,
+		KeyPassphraseEnv: jumpHost.KeyPassphraseEnv,
+		Agent:            jumpHost.Agent,
+		PasswordAuth:     jumpHost.PasswordAuth,
+		PasswordEnv:      jumpHost.PasswordEnv,
+	}
 }
 
 type KubeImages struct {
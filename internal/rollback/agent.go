@@ -14,6 +14,8 @@
 package rollback
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 )
@@ -34,7 +36,7 @@ func (exe *agentExecutor) GetName() string {
 	return exe.name
 }
 
-func (exe *agentExecutor) Execute() error {
+func (exe *agentExecutor) Execute(ctx context.Context) error {
 	ns, err := config.GetNamespace(exe.namespace)
 	if err != nil {
 		return err
@@ -0,0 +1,79 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package rollback
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	deployapplication "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/application"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+)
+
+type applicationExecutor struct {
+	namespace string
+	name      string
+	revision  int
+}
+
+func newApplicationExecutor(opt Options) *applicationExecutor {
+	return &applicationExecutor{
+		namespace: opt.Namespace,
+		name:      opt.Name,
+		revision:  opt.Revision,
+	}
+}
+
+func (exe *applicationExecutor) GetName() string {
+	return exe.name
+}
+
+// getRevision returns the revision to roll back to, the previous one by default
+func (exe *applicationExecutor) getRevision() (config.Revision, error) {
+	if exe.revision != 0 {
+		return config.GetRevision(exe.namespace, config.ApplicationKind, exe.name, exe.revision)
+	}
+	revisions, err := config.GetRevisions(exe.namespace, config.ApplicationKind, exe.name)
+	if err != nil {
+		return config.Revision{}, err
+	}
+	if len(revisions) < 2 {
+		return config.Revision{}, util.NewInputError(fmt.Sprintf("Application %s has no previous revision to roll back to", exe.name))
+	}
+	return revisions[len(revisions)-2], nil
+}
+
+// Execute deploys the spec of the revision again, which becomes the latest revision
+func (exe *applicationExecutor) Execute(ctx context.Context) error {
+	revision, err := exe.getRevision()
+	if err != nil {
+		return err
+	}
+
+	deployExe, err := deployapplication.NewExecutor(deployapplication.Options{
+		Namespace: exe.namespace,
+		Yaml:      []byte(revision.Spec),
+		Name:      exe.name,
+	})
+	if err != nil {
+		return err
+	}
+	util.SpinStart(fmt.Sprintf("Rolling back Application %s to revision %d", exe.name, revision.Number))
+	if err := deployExe.Execute(ctx); err != nil {
+		return err
+	}
+
+	return config.AddRevision(exe.namespace, config.ApplicationKind, exe.name, []byte(revision.Spec))
+}
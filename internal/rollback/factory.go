@@ -22,12 +22,22 @@ type Options struct {
 	ResourceType string
 	Namespace    string
 	Name         string
+	// Revision is the revision of an application to roll back to, 0 is the previous one
+	Revision int
 }
 
 func NewExecutor(opt Options) (execute.Executor, error) {
+	if opt.Revision < 0 {
+		return nil, util.NewInputError("Revision cannot be negative")
+	}
 	switch opt.ResourceType {
 	case "agent":
+		if opt.Revision != 0 {
+			return nil, util.NewInputError("Agents cannot be rolled back to a revision")
+		}
 		return newAgentExecutor(opt), nil
+	case "application":
+		return newApplicationExecutor(opt), nil
 	default:
 		return nil, util.NewInputError("Unsupported resource: " + opt.ResourceType)
 	}
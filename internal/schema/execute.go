@@ -0,0 +1,106 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+)
+
+// Filename is the name of the file of the schema of all kinds
+const Filename = "iofogctl.json"
+
+type Options struct {
+	Kind      string
+	OutputDir string
+}
+
+// Execute prints the JSON Schema of the kind, or of all kinds, or writes them to the output directory
+func Execute(opt *Options) error {
+	kinds := GetKinds()
+	if opt.Kind != "" {
+		kind, err := getKind(opt.Kind)
+		if err != nil {
+			return err
+		}
+		kinds = []config.Kind{kind}
+	}
+
+	if opt.OutputDir == "" {
+		return write(os.Stdout, getTitle(kinds), kinds)
+	}
+
+	if err := os.MkdirAll(opt.OutputDir, 0755); err != nil {
+		return err
+	}
+	if len(kinds) > 1 {
+		if err := writeFile(filepath.Join(opt.OutputDir, Filename), getTitle(kinds), kinds); err != nil {
+			return err
+		}
+	}
+	for _, kind := range kinds {
+		filename := filepath.Join(opt.OutputDir, string(kind)+".json")
+		if err := writeFile(filename, getTitle([]config.Kind{kind}), []config.Kind{kind}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func getKind(name string) (config.Kind, error) {
+	kinds := GetKinds()
+	names := []string{}
+	for _, kind := range kinds {
+		if strings.EqualFold(string(kind), name) {
+			return kind, nil
+		}
+		names = append(names, string(kind))
+	}
+	return "", util.NewInputError(fmt.Sprintf("Unsupported kind %s, expected one of: %s", name, strings.Join(names, ", ")))
+}
+
+func getTitle(kinds []config.Kind) string {
+	if len(kinds) == 1 {
+		return "iofogctl " + string(kinds[0])
+	}
+	return "iofogctl"
+}
+
+func writeFile(filename, title string, kinds []config.Kind) error {
+	file, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err := write(file, title, kinds); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+func write(writer io.Writer, title string, kinds []config.Kind) error {
+	data, err := json.MarshalIndent(Generate(title, kinds), "", "  ")
+	if err != nil {
+		return util.NewInternalError(fmt.Sprintf("Failed to encode the schema: %s", err.Error()))
+	}
+	_, err = fmt.Fprintln(writer, string(data))
+	return err
+}
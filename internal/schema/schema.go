@@ -0,0 +1,198 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package schema
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/apps"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
+)
+
+const draft = "http://json-schema.org/draft-07/schema#"
+
+// SpecTypes are the types the spec of each deployable kind is decoded into
+var SpecTypes = map[config.Kind]reflect.Type{
+	config.ApplicationKind:            reflect.TypeOf(rsc.Application{}),
+	config.ApplicationTemplateKind:    reflect.TypeOf(rsc.ApplicationTemplate{}),
+	config.MicroserviceKind:           reflect.TypeOf(rsc.Microservice{}),
+	config.CatalogItemKind:            reflect.TypeOf(apps.CatalogItem{}),
+	config.EdgeResourceKind:           reflect.TypeOf(rsc.EdgeResource{}),
+	config.KubernetesControlPlaneKind: reflect.TypeOf(rsc.KubernetesControlPlane{}),
+	config.RemoteControlPlaneKind:     reflect.TypeOf(rsc.RemoteControlPlane{}),
+	config.LocalControlPlaneKind:      reflect.TypeOf(rsc.LocalControlPlane{}),
+	config.RemoteControllerKind:       reflect.TypeOf(rsc.RemoteController{}),
+	config.LocalControllerKind:        reflect.TypeOf(rsc.LocalController{}),
+	config.RemoteAgentKind:            reflect.TypeOf(rsc.RemoteAgent{}),
+	config.LocalAgentKind:             reflect.TypeOf(rsc.LocalAgent{}),
+	config.AgentConfigKind:            reflect.TypeOf(rsc.AgentConfiguration{}),
+	config.RegistryKind:               reflect.TypeOf(rsc.Registry{}),
+	config.VolumeKind:                 reflect.TypeOf(rsc.Volume{}),
+	config.RouteKind:                  reflect.TypeOf(rsc.Route{}),
+}
+
+// GetKinds returns the deployable kinds in alphabetical order
+func GetKinds() (kinds []config.Kind) {
+	for kind := range SpecTypes {
+		kinds = append(kinds, kind)
+	}
+	sort.Slice(kinds, func(i, j int) bool { return kinds[i] < kinds[j] })
+	return
+}
+
+// Field is a field of a struct along with the name of its YAML key
+type Field struct {
+	Name string
+	Type reflect.Type
+}
+
+// GetFields returns the fields of a struct the way the YAML decoder reads them, including those of inlined structs
+func GetFields(t reflect.Type) (fields []Field) {
+	for idx := 0; idx < t.NumField(); idx++ {
+		field := t.Field(idx)
+		if field.PkgPath != "" && !field.Anonymous {
+			continue
+		}
+		tag := field.Tag.Get("yaml")
+		if tag == "-" {
+			continue
+		}
+		name := strings.Split(tag, ",")[0]
+		if strings.Contains(tag, ",inline") {
+			fieldType := field.Type
+			if fieldType.Kind() == reflect.Ptr {
+				fieldType = fieldType.Elem()
+			}
+			fields = append(fields, GetFields(fieldType)...)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+		fields = append(fields, Field{Name: name, Type: field.Type})
+	}
+	return
+}
+
+// Schema is a JSON Schema
+type Schema map[string]interface{}
+
+// generator builds the schemas of types, sharing the definitions of named structs
+type generator struct {
+	definitions map[string]Schema
+}
+
+func newGenerator() *generator {
+	return &generator{definitions: make(map[string]Schema)}
+}
+
+// getDefinitionName returns the name of the definition of a struct, e.g. resource.RemoteAgent
+func getDefinitionName(t reflect.Type) string {
+	pkgPath := strings.Split(t.PkgPath(), "/")
+	return pkgPath[len(pkgPath)-1] + "." + t.Name()
+}
+
+// getSchema returns the schema of the values the YAML decoder accepts for the type
+func (gen *generator) getSchema(t reflect.Type) Schema {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Struct:
+		if t.Name() == "" {
+			return gen.getStructSchema(t)
+		}
+		name := getDefinitionName(t)
+		if _, found := gen.definitions[name]; !found {
+			// Register the definition before walking the fields in case the type is recursive
+			gen.definitions[name] = Schema{}
+			gen.definitions[name] = gen.getStructSchema(t)
+		}
+		return Schema{"$ref": "#/definitions/" + name}
+	case reflect.Map:
+		return Schema{"type": "object", "additionalProperties": gen.getSchema(t.Elem())}
+	case reflect.Slice, reflect.Array:
+		return Schema{"type": "array", "items": gen.getSchema(t.Elem())}
+	case reflect.String:
+		// The decoder converts any scalar into a string
+		return Schema{"type": []string{"string", "number", "boolean"}}
+	case reflect.Bool:
+		return Schema{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return Schema{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return Schema{"type": "number"}
+	}
+	return Schema{}
+}
+
+func (gen *generator) getStructSchema(t reflect.Type) Schema {
+	properties := make(map[string]Schema)
+	for _, field := range GetFields(t) {
+		properties[field.Name] = gen.getSchema(field.Type)
+	}
+	return Schema{
+		"type":                 "object",
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+}
+
+// getDocumentSchema returns the schema of the documents of the kinds, whose spec is checked against the schema of their kind
+func (gen *generator) getDocumentSchema(kinds []config.Kind) Schema {
+	properties := map[string]Schema{
+		"apiVersion": {"const": config.LatestAPIVersion},
+		"kind":       {"type": "string", "enum": kinds},
+		"metadata":   gen.getSchema(reflect.TypeOf(config.HeaderMetadata{})),
+		"spec":       {"type": "object"},
+	}
+	document := Schema{
+		"type":                 "object",
+		"required":             []string{"apiVersion", "kind", "spec"},
+		"properties":           properties,
+		"additionalProperties": false,
+	}
+	if len(kinds) == 1 {
+		properties["spec"] = gen.getSchema(SpecTypes[kinds[0]])
+		return document
+	}
+
+	conditions := []Schema{}
+	for _, kind := range kinds {
+		conditions = append(conditions, Schema{
+			"if": Schema{
+				"properties": map[string]Schema{"kind": {"const": kind}},
+				"required":   []string{"kind"},
+			},
+			"then": Schema{
+				"properties": map[string]Schema{"spec": gen.getSchema(SpecTypes[kind])},
+			},
+		})
+	}
+	document["allOf"] = conditions
+	return document
+}
+
+// Generate returns the JSON Schema of the YAML documents of the kinds
+func Generate(title string, kinds []config.Kind) Schema {
+	gen := newGenerator()
+	schema := gen.getDocumentSchema(kinds)
+	schema["$schema"] = draft
+	schema["title"] = title
+	schema["definitions"] = gen.definitions
+	return schema
+}
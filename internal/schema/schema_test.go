@@ -0,0 +1,80 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package schema
+
+import (
+	"encoding/json"
+	"regexp"
+	"testing"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+)
+
+func TestGenerate(t *testing.T) {
+	schema := Generate("iofogctl", GetKinds())
+	data, err := json.Marshal(schema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every reference must resolve to a definition
+	definitions := schema["definitions"].(map[string]Schema)
+	for _, match := range regexp.MustCompile(`"\$ref":"#/definitions/([^"]+)"`).FindAllStringSubmatch(string(data), -1) {
+		if _, found := definitions[match[1]]; !found {
+			t.Errorf("Reference to missing definition %s", match[1])
+		}
+	}
+
+	if conditions := schema["allOf"].([]Schema); len(conditions) != len(SpecTypes) {
+		t.Errorf("Expected %d conditions, found %d", len(SpecTypes), len(conditions))
+	}
+
+	agent := definitions["resource.RemoteAgent"]
+	if agent["additionalProperties"] != false {
+		t.Error("Expected unknown fields to be rejected")
+	}
+	if _, found := agent["properties"].(map[string]Schema)["host"]; !found {
+		t.Error("Expected host property in Agent")
+	}
+
+	// Fields of inlined structs belong to the struct itself
+	agentConfig := definitions["resource.AgentConfiguration"]["properties"].(map[string]Schema)
+	for _, name := range []string{"name", "upstreamRouters", "routerConfig"} {
+		if _, found := agentConfig[name]; !found {
+			t.Errorf("Expected %s property in AgentConfig", name)
+		}
+	}
+}
+
+func TestGenerateKind(t *testing.T) {
+	kind, err := getKind("agent")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kind != config.RemoteAgentKind {
+		t.Fatalf("Expected kind %s, found %s", config.RemoteAgentKind, kind)
+	}
+	schema := Generate("iofogctl Agent", []config.Kind{kind})
+	if _, found := schema["allOf"]; found {
+		t.Error("Expected no conditions for a single kind")
+	}
+	spec := schema["properties"].(map[string]Schema)["spec"]
+	if spec["$ref"] != "#/definitions/resource.RemoteAgent" {
+		t.Errorf("Unexpected spec schema %v", spec)
+	}
+
+	if _, err := getKind("Unknown"); err == nil {
+		t.Error("Expected an error for an unsupported kind")
+	}
+}
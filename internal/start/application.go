@@ -14,24 +14,35 @@
 package startapplication
 
 import (
+	"context"
+	"time"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/wait"
 )
 
 type Options struct {
 	Namespace string
 	Name      string
+	Wait      bool
+	// WaitTimeout bounds the wait, 0 waits until the command is cancelled
+	WaitTimeout time.Duration
 }
 
 type executor struct {
-	namespace string
-	name      string
+	namespace   string
+	name        string
+	wait        bool
+	waitTimeout time.Duration
 }
 
 func NewExecutor(opt Options) (exe execute.Executor) {
 	return &executor{
-		name:      opt.Name,
-		namespace: opt.Namespace,
+		name:        opt.Name,
+		namespace:   opt.Namespace,
+		wait:        opt.Wait,
+		waitTimeout: opt.WaitTimeout,
 	}
 }
 
@@ -39,7 +50,7 @@ func (exe *executor) GetName() string {
 	return exe.name
 }
 
-func (exe *executor) Execute() (err error) {
+func (exe *executor) Execute(ctx context.Context) (err error) {
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
 		return err
@@ -50,7 +61,12 @@ func (exe *executor) Execute() (err error) {
 		return err
 	}
 
-	_, err = clt.StartFlow(flow.ID)
+	if _, err = clt.StartFlow(flow.ID); err != nil {
+		return
+	}
 
+	if exe.wait {
+		return wait.Microservices(ctx, exe.namespace, []string{exe.name}, nil, exe.waitTimeout)
+	}
 	return
 }
@@ -14,6 +14,8 @@
 package stopapplication
 
 import (
+	"context"
+
 	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
 	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
 )
@@ -39,7 +41,7 @@ func (exe *executor) GetName() string {
 	return exe.name
 }
 
-func (exe *executor) Execute() (err error) {
+func (exe *executor) Execute(ctx context.Context) (err error) {
 	clt, err := clientutil.NewControllerClient(exe.namespace)
 	if err != nil {
 		return err
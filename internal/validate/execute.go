@@ -0,0 +1,227 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package validate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/plugin"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/schema"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"gopkg.in/yaml.v3"
+)
+
+type Options struct {
+	Namespace string
+	Input     execute.InputOptions
+}
+
+// Problem is an error found in a YAML file
+type Problem struct {
+	File    string
+	Line    int
+	Column  int
+	Message string
+}
+
+func (problem Problem) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s", problem.File, problem.Line, problem.Column, problem.Message)
+}
+
+// document is a YAML document of an input file
+type document struct {
+	file      string
+	kind      config.Kind
+	namespace string
+	name      string
+	node      *yaml.Node
+	kindNode  *yaml.Node
+	nameNode  *yaml.Node
+	specNode  *yaml.Node
+}
+
+func (doc *document) problem(node *yaml.Node, format string, args ...interface{}) Problem {
+	if node == nil {
+		node = doc.node
+	}
+	return Problem{File: doc.file, Line: node.Line, Column: node.Column, Message: fmt.Sprintf(format, args...)}
+}
+
+// Execute prints the problems of the documents of the input files
+func Execute(opt *Options) error {
+	inputs, err := execute.ReadInputs(&opt.Input)
+	if err != nil {
+		return err
+	}
+	problems := Validate(inputs, opt.Namespace)
+	for _, problem := range problems {
+		fmt.Println(problem.String())
+	}
+	if len(problems) > 0 {
+		return util.NewInputError(fmt.Sprintf("Found %d problem(s)", len(problems)))
+	}
+	return nil
+}
+
+// Validate checks the documents of the inputs against the specification of their kind, then the references between them.
+// The problems are sorted by input and position
+func Validate(inputs []execute.Input, namespace string) (problems []Problem) {
+	documents := []*document{}
+	fileOrder := make(map[string]int)
+	for idx, input := range inputs {
+		fileOrder[input.Name] = idx
+		inputDocuments, inputProblems := decodeDocuments(input)
+		problems = append(problems, inputProblems...)
+		for _, doc := range inputDocuments {
+			if doc.namespace == "" {
+				doc.namespace = namespace
+			}
+			problems = append(problems, validateDocument(doc, namespace)...)
+		}
+		documents = append(documents, inputDocuments...)
+	}
+	problems = append(problems, validateReferences(documents)...)
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].File != problems[j].File {
+			return fileOrder[problems[i].File] < fileOrder[problems[j].File]
+		}
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Column < problems[j].Column
+	})
+	return problems
+}
+
+// Matches the line of the syntax errors of the YAML decoder
+var syntaxErrorLineRegex = regexp.MustCompile(`^yaml: line (\d+): `)
+
+func decodeDocuments(input execute.Input) (documents []*document, problems []Problem) {
+	dec := yaml.NewDecoder(bytes.NewReader(input.Data))
+	for {
+		var node yaml.Node
+		err := dec.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			return
+		}
+		if err != nil {
+			// The decoder cannot recover from syntax errors
+			problem := Problem{File: input.Name, Line: 1, Column: 1, Message: err.Error()}
+			if match := syntaxErrorLineRegex.FindStringSubmatch(err.Error()); match != nil {
+				problem.Line, _ = strconv.Atoi(match[1])
+				problem.Message = err.Error()[len(match[0]):]
+			}
+			return documents, append(problems, problem)
+		}
+		if len(node.Content) == 0 || node.Content[0].Tag == "!!null" {
+			continue
+		}
+		doc := &document{file: input.Name, node: node.Content[0]}
+		if doc.node.Kind != yaml.MappingNode {
+			problems = append(problems, doc.problem(nil, "Expected a document with apiVersion, kind, metadata and spec fields"))
+			continue
+		}
+		doc.kindNode = getField(doc.node, "kind")
+		if doc.kindNode != nil {
+			doc.kind = config.Kind(doc.kindNode.Value)
+		}
+		if metadata := getField(doc.node, "metadata"); metadata != nil && metadata.Kind == yaml.MappingNode {
+			doc.nameNode = getField(metadata, "name")
+			if doc.nameNode != nil {
+				doc.name = doc.nameNode.Value
+			}
+			if namespace := getField(metadata, "namespace"); namespace != nil {
+				doc.namespace = namespace.Value
+			}
+		}
+		doc.specNode = getField(doc.node, "spec")
+		documents = append(documents, doc)
+	}
+}
+
+// validateDocument checks the header and the spec of a document
+func validateDocument(doc *document, namespace string) []Problem {
+	problems := doc.checkNode(doc.node, reflect.TypeOf(config.Header{}), "document")
+
+	if apiVersion := getField(doc.node, "apiVersion"); apiVersion == nil {
+		problems = append(problems, doc.problem(nil, "Missing apiVersion, expected %s", config.LatestAPIVersion))
+	} else if apiVersion.Value != config.LatestAPIVersion {
+		problems = append(problems, doc.problem(apiVersion, "Unsupported API version %s, expected %s", apiVersion.Value, config.LatestAPIVersion))
+	}
+	// Documents can be deployed to any existing namespace
+	if metadata := getField(doc.node, "metadata"); metadata != nil {
+		if docNamespace := getField(metadata, "namespace"); docNamespace != nil && docNamespace.Value != "" && docNamespace.Value != namespace {
+			if _, err := config.GetNamespace(docNamespace.Value); err != nil {
+				problems = append(problems, doc.problem(docNamespace, "Namespace %s does not exist", docNamespace.Value))
+			}
+		}
+	}
+
+	if doc.kindNode == nil {
+		return append(problems, doc.problem(nil, "Missing kind"))
+	}
+	specType, found := schema.SpecTypes[doc.kind]
+	if !found {
+		// The spec of the kinds handled by plugins is left to the plugins
+		if _, err := plugin.GetKindPath(doc.kind); err == nil {
+			return problems
+		}
+		return append(problems, doc.problem(doc.kindNode, "Unsupported kind %s", doc.kind))
+	}
+	if doc.specNode == nil {
+		return append(problems, doc.problem(nil, "Missing spec"))
+	}
+	specProblems := doc.checkNode(doc.specNode, specType, "spec")
+	if len(specProblems) > 0 {
+		return append(problems, specProblems...)
+	}
+
+	// Check the values of the spec once it can be decoded
+	validator, found := kindValidators[doc.kind]
+	if !found {
+		return problems
+	}
+	spec, err := yaml.Marshal(doc.specNode)
+	if err == nil {
+		err = validator(doc.name, spec)
+	}
+	if err != nil {
+		problems = append(problems, doc.problem(doc.specNode, "%s", strings.ReplaceAll(util.GetErrorMessage(err), "\n", " ")))
+	}
+	return problems
+}
+
+// getField returns the value of the key of a mapping node
+func getField(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for idx := 0; idx+1 < len(node.Content); idx += 2 {
+		if node.Content[idx].Value == key {
+			return node.Content[idx+1]
+		}
+	}
+	return nil
+}
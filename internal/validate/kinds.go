@@ -0,0 +1,173 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package validate
+
+import (
+	"fmt"
+
+	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/apps"
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	deployagent "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/agent"
+	deployagentconfig "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/agentconfig"
+	deploycatalogitem "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/catalogitem"
+	deploylocalcontroller "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/controller/local"
+	deployremotecontroller "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/controller/remote"
+	deployk8scontrolplane "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/controlplane/k8s"
+	deployregistry "github.com/eclipse-iofog/iofogctl/v3/internal/deploy/registry"
+	rsc "github.com/eclipse-iofog/iofogctl/v3/internal/resource"
+	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+	"gopkg.in/yaml.v2"
+)
+
+// kindValidators check the spec of each kind the way its deploy executor does, without reading the Namespace
+var kindValidators = map[config.Kind]func(name string, spec []byte) error{
+	config.MicroserviceKind: func(name string, spec []byte) error {
+		_, _, err := clientutil.ParseFQName(name, "Microservice")
+		return err
+	},
+	config.CatalogItemKind: func(name string, spec []byte) error {
+		var catalogItem apps.CatalogItem
+		if err := yaml.Unmarshal(spec, &catalogItem); err != nil {
+			return err
+		}
+		if name != "" {
+			catalogItem.Name = name
+		}
+		return deploycatalogitem.Validate(&catalogItem)
+	},
+	config.EdgeResourceKind: func(name string, spec []byte) error {
+		if name == "" {
+			return util.NewInputError("Did not specify metadata.name")
+		}
+		return util.IsLowerAlphanumeric("Edge Resource", name)
+	},
+	config.KubernetesControlPlaneKind: func(name string, spec []byte) error {
+		controlPlane, err := rsc.UnmarshallKubernetesControlPlane(spec)
+		if err != nil {
+			return err
+		}
+		return deployk8scontrolplane.Validate(&controlPlane)
+	},
+	config.RemoteControlPlaneKind: func(name string, spec []byte) error {
+		controlPlane, err := rsc.UnmarshallRemoteControlPlane(spec)
+		if err != nil {
+			return err
+		}
+		for idx := range controlPlane.Controllers {
+			if err := validateRemoteController(&controlPlane.Controllers[idx]); err != nil {
+				return err
+			}
+		}
+		return nil
+	},
+	config.LocalControlPlaneKind: func(name string, spec []byte) error {
+		controlPlane, err := rsc.UnmarshallLocalControlPlane(spec)
+		if err != nil {
+			return err
+		}
+		if controlPlane.Controller == nil {
+			return util.NewInputError("Local Control Plane must specify a Controller")
+		}
+		return deploylocalcontroller.Validate(controlPlane.Controller)
+	},
+	config.RemoteControllerKind: func(name string, spec []byte) error {
+		controller, err := rsc.UnmarshallRemoteController(spec)
+		if err != nil {
+			return err
+		}
+		if name != "" {
+			controller.Name = name
+		}
+		return validateRemoteController(&controller)
+	},
+	config.LocalControllerKind: func(name string, spec []byte) error {
+		controller, err := rsc.UnmarshallLocalController(spec)
+		if err != nil {
+			return err
+		}
+		if name != "" {
+			controller.Name = name
+		}
+		return deploylocalcontroller.Validate(&controller)
+	},
+	config.RemoteAgentKind: func(name string, spec []byte) error {
+		agent, err := rsc.UnmarshallRemoteAgent(spec)
+		if err != nil {
+			return err
+		}
+		if name != "" {
+			agent.Name = name
+		}
+		return deployagent.ValidateRemoteAgent(&agent)
+	},
+	config.LocalAgentKind: func(name string, spec []byte) error {
+		_, err := rsc.UnmarshallLocalAgent(spec)
+		return err
+	},
+	config.AgentConfigKind: func(name string, spec []byte) error {
+		agentConfig := rsc.AgentConfiguration{}
+		if err := yaml.Unmarshal(spec, &agentConfig); err != nil {
+			return err
+		}
+		if agentConfig.Name == "" {
+			agentConfig.Name = name
+		}
+		return deployagentconfig.Validate(&agentConfig)
+	},
+	config.RegistryKind: func(name string, spec []byte) error {
+		var registry rsc.Registry
+		if err := yaml.Unmarshal(spec, &registry); err != nil {
+			return err
+		}
+		if registry.Private == nil {
+			registry.Private = new(bool)
+		}
+		return deployregistry.Validate(registry, true)
+	},
+	config.VolumeKind: func(name string, spec []byte) error {
+		var volume rsc.Volume
+		if err := yaml.Unmarshal(spec, &volume); err != nil {
+			return err
+		}
+		if name != "" {
+			volume.Name = name
+		}
+		return util.IsLowerAlphanumeric("Volume", volume.Name)
+	},
+	config.RouteKind: func(name string, spec []byte) error {
+		var route rsc.Route
+		if err := yaml.Unmarshal(spec, &route); err != nil {
+			return err
+		}
+		if route.Name == "" && name == "" {
+			return util.NewInputError("Did not specify metadata.name or spec.name")
+		}
+		_, routeName, err := clientutil.ParseFQName(name, "Route")
+		if err != nil {
+			return err
+		}
+		if route.Name != "" && route.Name != routeName {
+			return util.NewInputError(fmt.Sprintf("Mismatch between metadata.name [%s] and spec.name [%s]", name, route.Name))
+		}
+		return nil
+	},
+}
+
+func validateRemoteController(controller *rsc.RemoteController) error {
+	if err := deployremotecontroller.Validate(controller); err != nil {
+		return err
+	}
+	return controller.ValidateSSH()
+}
@@ -0,0 +1,180 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package validate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/config"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/iofog"
+	"gopkg.in/yaml.v3"
+)
+
+// references are the resources declared by the documents which other documents can refer to
+type references struct {
+	namespace string
+	// Agents declared by the documents or deployed in the Namespace
+	agents map[string]bool
+	// Microservices of each Application declared by the documents
+	applications map[string]map[string]bool
+}
+
+// validateReferences checks that the documents are unique within their namespace,
+// and that they refer to Agents and Microservices which exist in their namespace
+func validateReferences(documents []*document) (problems []Problem) {
+	namespaces := []string{}
+	documentsByNamespace := make(map[string][]*document)
+	for _, doc := range documents {
+		if _, found := documentsByNamespace[doc.namespace]; !found {
+			namespaces = append(namespaces, doc.namespace)
+		}
+		documentsByNamespace[doc.namespace] = append(documentsByNamespace[doc.namespace], doc)
+	}
+	for _, namespace := range namespaces {
+		problems = append(problems, validateNamespaceReferences(documentsByNamespace[namespace], namespace)...)
+	}
+	return problems
+}
+
+func validateNamespaceReferences(documents []*document, namespace string) (problems []Problem) {
+	refs := references{
+		namespace:    namespace,
+		agents:       map[string]bool{iofog.VanillaRouterAgentName: true},
+		applications: make(map[string]map[string]bool),
+	}
+	if ns, err := config.GetNamespace(namespace); err == nil {
+		for _, agent := range ns.GetAgents() {
+			refs.agents[agent.GetName()] = true
+		}
+	}
+
+	declared := make(map[string]*document)
+	for _, doc := range documents {
+		if doc.name == "" {
+			continue
+		}
+		key := fmt.Sprintf("%s/%s", doc.kind, doc.name)
+		if first, found := declared[key]; found {
+			problems = append(problems, doc.problem(doc.nameNode, "Duplicate %s %s, first declared at %s:%d:%d", doc.kind, doc.name, first.file, first.nameNode.Line, first.nameNode.Column))
+			continue
+		}
+		declared[key] = doc
+		refs.declare(doc)
+	}
+
+	for _, doc := range documents {
+		if doc.specNode == nil {
+			continue
+		}
+		problems = append(problems, refs.check(doc)...)
+	}
+	return problems
+}
+
+func (refs *references) declare(doc *document) {
+	switch doc.kind {
+	case config.RemoteAgentKind, config.LocalAgentKind:
+		refs.agents[doc.name] = true
+	case config.ApplicationKind:
+		msvcs := refs.getApplication(doc.name)
+		for _, msvc := range getItems(doc.specNode, "microservices") {
+			if name := getField(msvc, "name"); name != nil {
+				msvcs[name.Value] = true
+			}
+		}
+	case config.MicroserviceKind:
+		if appName, msvcName, found := strings.Cut(doc.name, "/"); found {
+			refs.getApplication(appName)[msvcName] = true
+		}
+	}
+}
+
+func (refs *references) getApplication(name string) map[string]bool {
+	if _, found := refs.applications[name]; !found {
+		refs.applications[name] = make(map[string]bool)
+	}
+	return refs.applications[name]
+}
+
+func (refs *references) check(doc *document) (problems []Problem) {
+	switch doc.kind {
+	case config.VolumeKind:
+		for _, agent := range getItems(doc.specNode, "agents") {
+			problems = append(problems, refs.checkAgent(doc, agent)...)
+		}
+	case config.MicroserviceKind:
+		problems = refs.checkMicroservice(doc, doc.specNode)
+	case config.ApplicationKind:
+		for _, msvc := range getItems(doc.specNode, "microservices") {
+			problems = append(problems, refs.checkMicroservice(doc, msvc)...)
+		}
+		for _, route := range getItems(doc.specNode, "routes") {
+			problems = append(problems, refs.checkRoute(doc, doc.name, route)...)
+		}
+	case config.RouteKind:
+		if appName, _, found := strings.Cut(doc.name, "/"); found {
+			problems = refs.checkRoute(doc, appName, doc.specNode)
+		}
+	case config.AgentConfigKind:
+		for _, router := range getItems(doc.specNode, "upstreamRouters") {
+			problems = append(problems, refs.checkAgent(doc, router)...)
+		}
+		if router := getField(doc.specNode, "networkRouter"); router != nil {
+			problems = append(problems, refs.checkAgent(doc, router)...)
+		}
+	}
+	return problems
+}
+
+func (refs *references) checkAgent(doc *document, node *yaml.Node) []Problem {
+	if node.Kind != yaml.ScalarNode || node.Value == "" || refs.agents[node.Value] {
+		return nil
+	}
+	return []Problem{doc.problem(node, "Unknown Agent %s, it is neither declared nor deployed in Namespace %s", node.Value, refs.namespace)}
+}
+
+func (refs *references) checkMicroservice(doc *document, msvc *yaml.Node) []Problem {
+	if agent := getField(msvc, "agent"); agent != nil {
+		if name := getField(agent, "name"); name != nil {
+			return refs.checkAgent(doc, name)
+		}
+	}
+	return nil
+}
+
+// checkRoute checks the Microservices of a Route exist when its Application is declared
+func (refs *references) checkRoute(doc *document, appName string, route *yaml.Node) (problems []Problem) {
+	msvcs, found := refs.applications[appName]
+	if !found {
+		return nil
+	}
+	for _, field := range []string{"from", "to"} {
+		msvc := getField(route, field)
+		if msvc == nil || msvc.Kind != yaml.ScalarNode || msvcs[msvc.Value] {
+			continue
+		}
+		problems = append(problems, doc.problem(msvc, "Unknown Microservice %s in Application %s", msvc.Value, appName))
+	}
+	return problems
+}
+
+// getItems returns the items of the list of a mapping node
+func getItems(node *yaml.Node, key string) []*yaml.Node {
+	list := getField(node, key)
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return nil
+	}
+	return list.Content
+}
@@ -0,0 +1,93 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package validate
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/schema"
+	yamlv2 "gopkg.in/yaml.v2"
+	"gopkg.in/yaml.v3"
+)
+
+var unmarshalerType = reflect.TypeOf((*yamlv2.Unmarshaler)(nil)).Elem()
+
+// checkNode returns the problems of a node which cannot be decoded into the type.
+// The path of the node is used in the messages, e.g. spec.container.ports
+func (doc *document) checkNode(node *yaml.Node, t reflect.Type, path string) (problems []Problem) {
+	if node.Kind == yaml.AliasNode {
+		node = node.Alias
+	}
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if node.Tag == "!!null" || t.Kind() == reflect.Interface || reflect.PtrTo(t).Implements(unmarshalerType) {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			return []Problem{doc.problem(node, "%s must be a map", path)}
+		}
+		fields := make(map[string]reflect.Type)
+		names := []string{}
+		for _, field := range schema.GetFields(t) {
+			fields[field.Name] = field.Type
+			names = append(names, field.Name)
+		}
+		for idx := 0; idx+1 < len(node.Content); idx += 2 {
+			key, value := node.Content[idx], node.Content[idx+1]
+			fieldType, found := fields[key.Value]
+			if !found {
+				problems = append(problems, doc.problem(key, "Unknown field %s in %s, expected one of: %s", key.Value, path, strings.Join(names, ", ")))
+				continue
+			}
+			problems = append(problems, doc.checkNode(value, fieldType, path+"."+key.Value)...)
+		}
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			return []Problem{doc.problem(node, "%s must be a map", path)}
+		}
+		for idx := 0; idx+1 < len(node.Content); idx += 2 {
+			problems = append(problems, doc.checkNode(node.Content[idx+1], t.Elem(), path+"."+node.Content[idx].Value)...)
+		}
+	case reflect.Slice, reflect.Array:
+		if node.Kind != yaml.SequenceNode {
+			return []Problem{doc.problem(node, "%s must be a list", path)}
+		}
+		for idx, item := range node.Content {
+			problems = append(problems, doc.checkNode(item, t.Elem(), fmt.Sprintf("%s[%d]", path, idx))...)
+		}
+	case reflect.String:
+		if node.Kind != yaml.ScalarNode {
+			return []Problem{doc.problem(node, "%s must be a string", path)}
+		}
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			return []Problem{doc.problem(node, "%s must be a boolean", path)}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!int" {
+			return []Problem{doc.problem(node, "%s must be an integer", path)}
+		}
+	case reflect.Float32, reflect.Float64:
+		if node.Kind != yaml.ScalarNode || (node.Tag != "!!int" && node.Tag != "!!float") {
+			return []Problem{doc.problem(node, "%s must be a number", path)}
+		}
+	}
+	return problems
+}
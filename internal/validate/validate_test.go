@@ -0,0 +1,98 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package validate
+
+import (
+	"testing"
+
+	"github.com/eclipse-iofog/iofogctl/v3/internal/execute"
+)
+
+func TestValidate(t *testing.T) {
+	input := execute.Input{Name: "ecn.yaml", Data: []byte(`apiVersion: iofog.org/v3
+kind: Agent
+metadata:
+  name: agent-1
+spec:
+  host: 10.0.0.1
+  ssh:
+    user: foo
+    keyFile: ~/.ssh/id_rsa
+---
+apiVersion: iofog.org/v3
+kind: Application
+metadata:
+  name: app
+spec:
+  microservices:
+  - name: msvc-1
+    agent:
+      name: agent-2
+    container:
+      ports:
+      - internal: eighty
+  routes:
+  - name: route
+    from: msvc-1
+    to: msvc-3
+---
+apiVersion: iofog.org/v3
+kind: Volume
+metadata:
+  name: vol
+spec:
+  agents:
+  - agent-1
+  - agent-3
+  sourc: /tmp
+---
+apiVersion: iofog.org/v2
+kind: Gadget
+metadata:
+  name: gadget
+spec: {}
+---
+apiVersion: iofog.org/v3
+kind: Agent
+metadata:
+  name: agent-1
+spec:
+  ssh:
+    user: foo
+`)}
+
+	expected := []string{
+		"ecn.yaml:19:13: Unknown Agent agent-2, it is neither declared nor deployed in Namespace default",
+		"ecn.yaml:22:19: spec.microservices[0].container.ports[0].internal must be an integer",
+		"ecn.yaml:26:9: Unknown Microservice msvc-3 in Application app",
+		"ecn.yaml:35:5: Unknown Agent agent-3, it is neither declared nor deployed in Namespace default",
+		"ecn.yaml:36:3: Unknown field sourc in spec, expected one of: name, agents, source, destination, permissions",
+		"ecn.yaml:38:13: Unsupported API version iofog.org/v2, expected iofog.org/v3",
+		"ecn.yaml:39:7: Unsupported kind Gadget",
+		"ecn.yaml:47:9: Duplicate Agent agent-1, first declared at ecn.yaml:4:9",
+		"ecn.yaml:49:3: For Agents you must specify a non-empty value for host",
+	}
+	problems := Validate([]execute.Input{input}, "default")
+	if len(problems) != len(expected) {
+		for _, problem := range problems {
+			t.Log(problem.String())
+		}
+		t.Fatalf("Expected %d problems, got %d", len(expected), len(problems))
+	}
+	for idx := range expected {
+		if problems[idx].String() != expected[idx] {
+			t.Errorf("Expected %s, got %s", expected[idx], problems[idx].String())
+		}
+	}
+}
@@ -0,0 +1,147 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package wait
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
+	clientutil "github.com/eclipse-iofog/iofogctl/v3/internal/util/client"
+	"github.com/eclipse-iofog/iofogctl/v3/pkg/util"
+)
+
+const (
+	runningStatus = "RUNNING"
+	failedStatus  = "FAILED"
+)
+
+// DefaultTimeout is how long the microservices are waited for by default
+const DefaultTimeout = 5 * time.Minute
+
+// pollInterval is the delay between two reads of the statuses of the microservices
+var pollInterval = 5 * time.Second
+
+// status is the status of a microservice along with its fully qualified name
+type status struct {
+	name string
+	info client.MicroserviceStatusInfo
+}
+
+// Microservices waits until the microservices of the Applications and the microservices, named application/microservice,
+// are all RUNNING. It fails with the status of each microservice if any is FAILED, or if the timeout expires or the context
+// is done first. A timeout of 0 waits until the context is done
+func Microservices(ctx context.Context, namespace string, applications, microservices []string, timeout time.Duration) error {
+	if len(applications) == 0 && len(microservices) == 0 {
+		return nil
+	}
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	clt, err := clientutil.NewControllerClient(namespace)
+	if err != nil {
+		return err
+	}
+	getStatuses := func() (statuses []status, err error) {
+		seen := make(map[string]bool)
+		add := func(appName string, msvc *client.MicroserviceInfo) {
+			if seen[msvc.UUID] {
+				return
+			}
+			seen[msvc.UUID] = true
+			if appName == "" {
+				appName = msvc.Application
+			}
+			statuses = append(statuses, status{name: appName + "/" + msvc.Name, info: msvc.Status})
+		}
+		for _, appName := range applications {
+			msvcList, err := clt.GetMicroservicesByApplication(appName)
+			if err != nil {
+				return nil, err
+			}
+			for idx := range msvcList.Microservices {
+				add(appName, &msvcList.Microservices[idx])
+			}
+		}
+		for _, fqName := range microservices {
+			appName, msvcName, err := clientutil.ParseFQName(fqName, "Microservice")
+			if err != nil {
+				return nil, err
+			}
+			msvc, err := clt.GetMicroserviceByName(appName, msvcName)
+			if err != nil {
+				return nil, err
+			}
+			add(appName, msvc)
+		}
+		return statuses, nil
+	}
+	return waitForRunning(ctx, getStatuses)
+}
+
+func waitForRunning(ctx context.Context, getStatuses func() ([]status, error)) error {
+	for {
+		statuses, err := getStatuses()
+		if err != nil {
+			return err
+		}
+		running := 0
+		for _, msvcStatus := range statuses {
+			switch msvcStatus.info.Status {
+			case runningStatus:
+				running++
+			case failedStatus:
+				return newStatusError(fmt.Sprintf("Microservice %s failed", msvcStatus.name), statuses)
+			}
+		}
+		if running == len(statuses) {
+			util.SpinStop()
+			return nil
+		}
+		util.SpinStart(fmt.Sprintf("Waiting for microservices to be RUNNING (%d/%d)", running, len(statuses)))
+
+		if err := util.Sleep(ctx, pollInterval); err != nil {
+			if errors.Is(err, context.DeadlineExceeded) {
+				return newStatusError("Timed out waiting for microservices to be RUNNING", statuses)
+			}
+			return newStatusError("Stopped waiting for microservices to be RUNNING", statuses)
+		}
+	}
+}
+
+// newStatusError returns an error with the message followed by a table of the status of each microservice
+func newStatusError(message string, statuses []status) error {
+	buf := &bytes.Buffer{}
+	writer := tabwriter.NewWriter(buf, 16, 8, 1, '\t', 0)
+	fmt.Fprintf(writer, "MICROSERVICE\tSTATUS\tERROR\t\n")
+	for _, msvcStatus := range statuses {
+		statusName := msvcStatus.info.Status
+		if statusName == "" {
+			statusName = "-"
+		}
+		errMsg := msvcStatus.info.ErrorMessage
+		if errMsg == "" {
+			errMsg = "-"
+		}
+		fmt.Fprintf(writer, "%s\t%s\t%s\t\n", msvcStatus.name, statusName, errMsg)
+	}
+	writer.Flush()
+	return util.NewError(fmt.Sprintf("%s\n\n%s", message, buf.String()))
+}
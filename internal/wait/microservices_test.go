@@ -0,0 +1,54 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package wait
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/eclipse-iofog/iofog-go-sdk/v3/pkg/client"
+)
+
+func newStatuses(names ...string) func() ([]status, error) {
+	polls := 0
+	return func() ([]status, error) {
+		statuses := []status{{name: "app/msvc", info: client.MicroserviceStatusInfo{Status: names[polls]}}}
+		if polls < len(names)-1 {
+			polls++
+		}
+		return statuses, nil
+	}
+}
+
+func TestWaitForRunning(t *testing.T) {
+	pollInterval = time.Millisecond
+
+	if err := waitForRunning(context.Background(), newStatuses("PULLING", "STARTING", "RUNNING")); err != nil {
+		t.Errorf("Unexpected error: %s", err.Error())
+	}
+
+	err := waitForRunning(context.Background(), newStatuses("PULLING", "FAILED"))
+	if err == nil || !strings.Contains(err.Error(), "Microservice app/msvc failed") || !strings.Contains(err.Error(), "FAILED") {
+		t.Errorf("Expected the failed microservice to be reported, got: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	err = waitForRunning(ctx, newStatuses("PULLING"))
+	if err == nil || !strings.Contains(err.Error(), "Timed out") || !strings.Contains(err.Error(), "PULLING") {
+		t.Errorf("Expected a timeout with the status of the microservice, got: %v", err)
+	}
+}
@@ -66,5 +66,6 @@ func (agent *defaultAgent) getProvisionKey(controllerEndpoint string, user Iofog
 		return
 	}
 	key = provisionResponse.Key
+	util.RedactAudit(key)
 	return key, err
 }
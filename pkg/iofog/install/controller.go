@@ -14,6 +14,7 @@
 package install
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"strings"
@@ -38,7 +39,10 @@ type ControllerOptions struct {
 	User                string
 	Host                string
 	Port                int
-	PrivKeyFilename     string
+	Auth                util.SSHAuth
+	HostKey             string
+	JumpHosts           []util.SSHJumpHost
+	Privilege           util.SSHPrivilege
 	Version             string
 	Repo                string
 	Token               string
@@ -63,14 +67,19 @@ type Controller struct {
 	ctrlDir  string
 	iofogDir string
 	svcDir   string
+	ctx      context.Context
 }
 
 func NewController(options *ControllerOptions) (*Controller, error) {
-	ssh, err := util.NewSecureShellClient(options.User, options.Host, options.PrivKeyFilename)
+	ssh, err := util.NewSecureShellClient(options.User, options.Host, options.Auth)
 	if err != nil {
 		return nil, err
 	}
 	ssh.SetPort(options.Port)
+	ssh.SetHostKey(options.HostKey)
+	ssh.SetJumpHosts(options.JumpHosts)
+	ssh.SetPrivilege(options.Privilege)
+	util.RedactAudit(options.Token)
 	if options.Version == "" || options.Version == "latest" {
 		options.Version = util.GetControllerVersion()
 	}
@@ -80,9 +89,16 @@ func NewController(options *ControllerOptions) (*Controller, error) {
 		iofogDir:          "/etc/iofog",
 		ctrlDir:           "/etc/iofog/controller",
 		svcDir:            "/etc/iofog/controller/service",
+		ctx:               context.Background(),
 	}, nil
 }
 
+// SetContext makes the Controller abort its SSH commands and waits once the context is done
+func (ctrl *Controller) SetContext(ctx context.Context) {
+	ctrl.ctx = ctx
+	ctrl.ssh.SetContext(ctx)
+}
+
 func (ctrl *Controller) SetControllerExternalDatabase(host, user, password, provider, databaseName string, port int) {
 	if provider == "" {
 		provider = "postgres"
@@ -98,6 +114,7 @@ func (ctrl *Controller) SetControllerExternalDatabase(host, user, password, prov
 		password:     password,
 		port:         port,
 	}
+	util.RedactAudit(password)
 }
 
 func (ctrl *Controller) CopyScript(srcDir, filename, destDir string) (err error) {
@@ -276,7 +293,7 @@ func (ctrl *Controller) Install() (err error) {
 
 	// Wait for API
 	endpoint := fmt.Sprintf("%s:%s", ctrl.Host, iofog.ControllerPortString)
-	if err = WaitForControllerAPI(endpoint); err != nil {
+	if err = WaitForControllerAPI(ctrl.ctx, endpoint); err != nil {
 		return
 	}
 
@@ -307,7 +324,7 @@ func (ctrl *Controller) Stop() (err error) {
 	return
 }
 
-func WaitForControllerAPI(endpoint string) (err error) {
+func WaitForControllerAPI(ctx context.Context, endpoint string) (err error) {
 	baseURL, err := util.GetBaseURL(endpoint)
 	if err != nil {
 		return err
@@ -321,7 +338,9 @@ func WaitForControllerAPI(endpoint string) (err error) {
 			return
 		}
 		// Connection failed, wait and retry
-		time.Sleep(time.Millisecond * 1000)
+		if err = util.Sleep(ctx, time.Millisecond*1000); err != nil {
+			return
+		}
 		seconds++
 	}
 
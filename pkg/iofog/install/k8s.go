@@ -51,6 +51,7 @@ type Kubernetes struct {
 	operator      *microservice
 	services      cpv3.Services
 	images        cpv3.Images
+	ctx           context.Context
 }
 
 // NewKubernetes constructs an object to manage cluster
@@ -77,9 +78,15 @@ func NewKubernetes(configFilename, namespace string) (*Kubernetes, error) {
 		extsClientset: extsClientset,
 		ns:            namespace,
 		operator:      newOperatorMicroservice(),
+		ctx:           context.Background(),
 	}, nil
 }
 
+// SetContext makes the client abort requests and waits once the context is done
+func (k8s *Kubernetes) SetContext(ctx context.Context) {
+	k8s.ctx = ctx
+}
+
 func (k8s *Kubernetes) SetOperatorImage(image string) {
 	if image != "" {
 		k8s.operator.containers[0].image = image
@@ -121,7 +128,7 @@ func (k8s *Kubernetes) SetControllerImage(image string) {
 }
 
 func (k8s *Kubernetes) enableCustomResources() error {
-	ctx := context.Background()
+	ctx := k8s.ctx
 	// Control Plane and App
 	for _, crd := range []*extsv1.CustomResourceDefinition{iofogv3.NewControlPlaneCustomResource(), iofogv3.NewAppCustomResource()} {
 		// Try create new
@@ -174,7 +181,7 @@ func (k8s *Kubernetes) CreateControlPlane(conf *ControllerConfig) (endpoint stri
 			Name: k8s.ns,
 		},
 	}
-	if _, err = k8s.clientset.CoreV1().Namespaces().Create(context.Background(), ns, metav1.CreateOptions{}); err != nil {
+	if _, err = k8s.clientset.CoreV1().Namespaces().Create(k8s.ctx, ns, metav1.CreateOptions{}); err != nil {
 		if !k8serrors.IsAlreadyExists(err) {
 			return
 		}
@@ -194,7 +201,7 @@ func (k8s *Kubernetes) CreateControlPlane(conf *ControllerConfig) (endpoint stri
 	}
 	var cp cpv3.ControlPlane
 	found := true
-	if err = k8s.opClient.Get(context.Background(), cpKey, &cp); err != nil {
+	if err = k8s.opClient.Get(k8s.ctx, cpKey, &cp); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return
 		}
@@ -220,13 +227,13 @@ func (k8s *Kubernetes) CreateControlPlane(conf *ControllerConfig) (endpoint stri
 	// Create or update Control Plane
 	if found {
 		Verbose("Updating existing Control Plane")
-		if err = k8s.opClient.Update(context.Background(), &cp); err != nil {
+		if err = k8s.opClient.Update(k8s.ctx, &cp); err != nil {
 			return
 		}
 	} else {
 		cp.SetConditionDeploying(nil)
 		Verbose("Deploying new Control Plane")
-		if err = k8s.opClient.Create(context.Background(), &cp); err != nil {
+		if err = k8s.opClient.Create(k8s.ctx, &cp); err != nil {
 			return
 		}
 	}
@@ -242,6 +249,8 @@ func (k8s *Kubernetes) CreateControlPlane(conf *ControllerConfig) (endpoint stri
 	go k8s.monitorOperator(errCh)
 	select {
 	case err = <-errCh:
+	case <-k8s.ctx.Done():
+		err = k8s.ctx.Err()
 	case <-time.After(240 * time.Second):
 		err = util.NewInternalError("Failed to wait for Default Router registration")
 	}
@@ -251,7 +260,7 @@ func (k8s *Kubernetes) CreateControlPlane(conf *ControllerConfig) (endpoint stri
 
 func (k8s *Kubernetes) getReadyPod() (readyPod *corev1.Pod, err error) {
 	// Check operator logs
-	pods, err := k8s.clientset.CoreV1().Pods(k8s.ns).List(context.Background(), metav1.ListOptions{
+	pods, err := k8s.clientset.CoreV1().Pods(k8s.ns).List(k8s.ctx, metav1.ListOptions{
 		LabelSelector: "name=iofog-operator", // TODO: Decouple this
 	})
 	if err != nil {
@@ -285,7 +294,10 @@ func (k8s *Kubernetes) getReadyPod() (readyPod *corev1.Pod, err error) {
 func (k8s *Kubernetes) monitorOperator(errCh chan error) {
 	errSuffix := "while awaiting finalization of Control Plane"
 	for {
-		time.Sleep(2 * time.Second)
+		if err := util.Sleep(k8s.ctx, 2*time.Second); err != nil {
+			errCh <- err
+			return
+		}
 		pod, err := k8s.getReadyPod()
 		if err != nil {
 			errCh <- fmt.Errorf("%s %s", err.Error(), errSuffix)
@@ -297,7 +309,7 @@ func (k8s *Kubernetes) monitorOperator(errCh chan error) {
 		}
 		// Get the logs of ready Pod
 		req := k8s.clientset.CoreV1().Pods(k8s.ns).GetLogs(pod.Name, &corev1.PodLogOptions{})
-		podLogs, err := req.Stream(context.Background())
+		podLogs, err := req.Stream(k8s.ctx)
 		if err != nil {
 			errCh <- util.NewInternalError("Error opening Operator Pod log stream " + errSuffix)
 			return
@@ -311,7 +323,7 @@ func (k8s *Kubernetes) monitorOperator(errCh chan error) {
 
 		// Check controlplane resource status
 		var cp cpv3.ControlPlane
-		if err = k8s.opClient.Get(context.Background(), opclient.ObjectKey{
+		if err = k8s.opClient.Get(k8s.ctx, opclient.ObjectKey{
 			Name:      cpInstanceName,
 			Namespace: k8s.ns,
 		}, &cp); err != nil {
@@ -349,7 +361,7 @@ func (k8s *Kubernetes) deleteOperator() (err error) {
 	// Resource name for deletions
 	name := k8s.operator.name
 
-	ctx := context.Background()
+	ctx := k8s.ctx
 
 	// Service Account
 	if err = k8s.clientset.CoreV1().ServiceAccounts(k8s.ns).Delete(ctx, name, metav1.DeleteOptions{}); err != nil {
@@ -383,7 +395,7 @@ func (k8s *Kubernetes) deleteOperator() (err error) {
 }
 
 func (k8s *Kubernetes) createOperator() (err error) {
-	ctx := context.Background()
+	ctx := k8s.ctx
 
 	// Service Account
 	opSvcAcc := newServiceAccount(k8s.ns, k8s.operator)
@@ -439,7 +451,7 @@ func (k8s *Kubernetes) DeleteControlPlane() error {
 			Namespace: k8s.ns,
 		},
 	}
-	if err := k8s.opClient.Delete(context.Background(), cp); err != nil {
+	if err := k8s.opClient.Delete(k8s.ctx, cp); err != nil {
 		if !k8serrors.IsNotFound(err) {
 			return err
 		}
@@ -452,7 +464,7 @@ func (k8s *Kubernetes) DeleteControlPlane() error {
 
 	// Delete Namespace
 	if k8s.ns != "default" {
-		if err := k8s.clientset.CoreV1().Namespaces().Delete(context.Background(), k8s.ns, metav1.DeleteOptions{}); err != nil {
+		if err := k8s.clientset.CoreV1().Namespaces().Delete(k8s.ctx, k8s.ns, metav1.DeleteOptions{}); err != nil {
 			if !k8serrors.IsNotFound(err) {
 				return err
 			}
@@ -464,7 +476,7 @@ func (k8s *Kubernetes) DeleteControlPlane() error {
 
 func (k8s *Kubernetes) waitForService(name string, targetPort int32) (addr string, nodePort int32, err error) {
 	// Get watch handler to observe changes to services
-	watch, err := k8s.clientset.CoreV1().Services(k8s.ns).Watch(context.Background(), metav1.ListOptions{})
+	watch, err := k8s.clientset.CoreV1().Services(k8s.ns).Watch(k8s.ctx, metav1.ListOptions{})
 	if err != nil {
 		return
 	}
@@ -518,6 +530,9 @@ func (k8s *Kubernetes) waitForService(name string, targetPort int32) (addr strin
 			return
 		}
 	}
+	if err = k8s.ctx.Err(); err != nil {
+		return addr, nodePort, err
+	}
 	err = util.NewError("Did not receive any events from Kuberenetes API Server")
 	return addr, nodePort, err
 }
@@ -540,7 +555,7 @@ func (k8s *Kubernetes) getPort(svc *corev1.Service, name string, targetPort int3
 func (k8s *Kubernetes) getClusterIPAddress(name string) (addr string, err error) {
 	// Get a list of K8s nodes and return one of their external IPs
 	var nodeList *corev1.NodeList
-	nodeList, err = k8s.clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	nodeList, err = k8s.clientset.CoreV1().Nodes().List(k8s.ctx, metav1.ListOptions{})
 	if err != nil {
 		return
 	}
@@ -562,7 +577,7 @@ func (k8s *Kubernetes) getClusterIPAddress(name string) (addr string, err error)
 func (k8s *Kubernetes) getNodePortAddress(name string) (addr string, err error) {
 	// Get a list of K8s nodes and return one of their external IPs
 	var nodeList *corev1.NodeList
-	nodeList, err = k8s.clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	nodeList, err = k8s.clientset.CoreV1().Nodes().List(k8s.ctx, metav1.ListOptions{})
 	if err != nil {
 		return
 	}
@@ -628,7 +643,7 @@ func (k8s *Kubernetes) SetProxyService(svcType, ip string) {
 }
 
 func (k8s *Kubernetes) ExistsInNamespace(namespace string) error {
-	ctx := context.Background()
+	ctx := k8s.ctx
 	// Check namespace exists
 	if _, err := k8s.clientset.CoreV1().Namespaces().Get(ctx, namespace, metav1.GetOptions{}); err != nil {
 		if k8serrors.IsNotFound(err) {
@@ -662,7 +677,7 @@ func (k8s *Kubernetes) GetControllerEndpoint() (endpoint string, err error) {
 func (k8s *Kubernetes) GetControllerPods() (podNames []Pod, err error) {
 	podNames = []Pod{}
 	// List pods
-	pods, err := k8s.clientset.CoreV1().Pods(k8s.ns).List(context.Background(), metav1.ListOptions{})
+	pods, err := k8s.clientset.CoreV1().Pods(k8s.ns).List(k8s.ctx, metav1.ListOptions{})
 	if err != nil {
 		return
 	}
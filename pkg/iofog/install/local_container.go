@@ -41,6 +41,7 @@ import (
 // LocalContainer struct to encapsulate utilities around docker
 type LocalContainer struct {
 	client *client.Client
+	ctx    context.Context
 }
 
 // ExecResult contains the output of a command ran into docker exec
@@ -161,12 +162,18 @@ func NewLocalContainerClient() (*LocalContainer, error) {
 	}
 	return &LocalContainer{
 		client: cli,
+		ctx:    context.Background(),
 	}, nil
 }
 
+// SetContext makes the client abort Docker requests and waits once the context is done
+func (lc *LocalContainer) SetContext(ctx context.Context) {
+	lc.ctx = ctx
+}
+
 // GetLogsByName returns the logs of the container specified by name
 func (lc *LocalContainer) GetLogsByName(name string) (stdout, stderr string, err error) {
-	ctx := context.Background()
+	ctx := lc.ctx
 	r, err := lc.client.ContainerLogs(ctx, name, types.ContainerLogsOptions{ShowStdout: true, ShowStderr: true})
 	if err != nil {
 		return
@@ -188,7 +195,7 @@ func (lc *LocalContainer) GetLogsByName(name string) (stdout, stderr string, err
 }
 
 func (lc *LocalContainer) GetContainerByName(name string) (types.Container, error) {
-	ctx := context.Background()
+	ctx := lc.ctx
 	// List containers
 	containers, err := lc.client.ContainerList(ctx, types.ContainerListOptions{})
 	if err != nil {
@@ -208,13 +215,13 @@ func (lc *LocalContainer) GetContainerByName(name string) (types.Container, erro
 }
 
 func (lc *LocalContainer) ListContainers() ([]types.Container, error) {
-	ctx := context.Background()
+	ctx := lc.ctx
 	return lc.client.ContainerList(ctx, types.ContainerListOptions{})
 }
 
 // CleanContainer stops and remove a container based on a container name
 func (lc *LocalContainer) CleanContainer(name string) error {
-	ctx := context.Background()
+	ctx := lc.ctx
 
 	container, err := lc.GetContainerByName(name)
 	if err != nil {
@@ -230,7 +237,7 @@ func (lc *LocalContainer) CleanContainer(name string) error {
 }
 
 func (lc *LocalContainer) CleanContainerByID(id string) error {
-	ctx := context.Background()
+	ctx := lc.ctx
 
 	// Stop container if running (ignore error if there is no running container)
 	if err := lc.client.ContainerStop(ctx, id, nil); err != nil {
@@ -271,7 +278,7 @@ func (lc *LocalContainer) waitForImage(image string, counter int8) error {
 	if counter >= 18 { // 180 seconds
 		return util.NewInternalError("Could not find newly pulled image: " + image)
 	}
-	ctx := context.Background()
+	ctx := lc.ctx
 	imgs, listErr := lc.client.ImageList(ctx, types.ImageListOptions{All: true})
 	if listErr != nil {
 		return util.NewError(fmt.Sprintf("Could not list local images: %v\n", listErr))
@@ -283,13 +290,15 @@ func (lc *LocalContainer) waitForImage(image string, counter int8) error {
 			}
 		}
 	}
-	time.Sleep(10 * time.Second)
+	if err := util.Sleep(ctx, 10*time.Second); err != nil {
+		return err
+	}
 	return lc.waitForImage(image, counter+1)
 }
 
 // DeployContainer deploys a container based on an image and a port mappin
 func (lc *LocalContainer) DeployContainer(containerConfig *LocalContainerConfig) (string, error) {
-	ctx := context.Background()
+	ctx := lc.ctx
 
 	portSet := nat.PortSet{}
 	portMap := nat.PortMap{}
@@ -406,13 +415,22 @@ func (lc *LocalContainer) WaitForCommand(containerName string, condition *regexp
 		if condition.MatchString(output.StdOut) {
 			return nil
 		}
-		time.Sleep(2 * time.Second)
+		if err := util.Sleep(lc.ctx, 2*time.Second); err != nil {
+			return err
+		}
 	}
 	return util.NewInternalError("Timed out waiting for container")
 }
 
 func (lc *LocalContainer) ExecuteCmd(name string, cmd []string) (execResult ExecResult, err error) {
-	ctx := context.Background()
+	ctx := lc.ctx
+	defer func() {
+		entry := util.AuditEntry{Type: util.AuditContainerCommand, Target: name, Command: strings.Join(cmd, " "), ExitStatus: execResult.ExitCode}
+		if err != nil {
+			entry.ExitStatus = -1
+		}
+		util.Audit(entry, err)
+	}()
 
 	container, err := lc.GetContainerByName(name)
 	if err != nil {
@@ -541,7 +559,7 @@ func compress(src string, buf io.Writer) error {
 }
 
 func (lc *LocalContainer) CopyToContainer(name, source, dest string) (err error) {
-	ctx := context.Background()
+	ctx := lc.ctx
 
 	container, err := lc.GetContainerByName(name)
 	if err != nil {
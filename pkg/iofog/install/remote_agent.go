@@ -14,6 +14,7 @@
 package install
 
 import (
+	"context"
 	"fmt"
 	"io/ioutil"
 	"path/filepath"
@@ -54,8 +55,8 @@ func (script *Entrypoint) getCommand() string {
 	return fmt.Sprintf("%s %s", script.destPath, args)
 }
 
-func NewRemoteAgent(user, host string, port int, privKeyFilename, agentName, agentUUID string) (*RemoteAgent, error) {
-	ssh, err := util.NewSecureShellClient(user, host, privKeyFilename)
+func NewRemoteAgent(user, host string, port int, auth util.SSHAuth, agentName, agentUUID string) (*RemoteAgent, error) {
+	ssh, err := util.NewSecureShellClient(user, host, auth)
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +110,26 @@ func NewRemoteAgent(user, host string, port int, privKeyFilename, agentName, age
 	return agent, nil
 }
 
+// SetContext makes the Agent abort its SSH commands once the context is done
+func (agent *RemoteAgent) SetContext(ctx context.Context) {
+	agent.ssh.SetContext(ctx)
+}
+
+// SetHostKey pins the key of the Agent host, see util.ParseHostKey
+func (agent *RemoteAgent) SetHostKey(hostKey string) {
+	agent.ssh.SetHostKey(hostKey)
+}
+
+// SetJumpHosts tunnels the SSH connection to the Agent host through the jump hosts
+func (agent *RemoteAgent) SetJumpHosts(jumpHosts []util.SSHJumpHost) {
+	agent.ssh.SetJumpHosts(jumpHosts)
+}
+
+// SetPrivilege sets how the commands are run as root on the Agent host
+func (agent *RemoteAgent) SetPrivilege(privilege util.SSHPrivilege) {
+	agent.ssh.SetPrivilege(privilege)
+}
+
 func (agent *RemoteAgent) CustomizeProcedures(dir string, procs *AgentProcedures) error {
 	// Format source directory of script files
 	dir, err := util.FormatPath(dir)
@@ -199,6 +220,7 @@ func (agent *RemoteAgent) SetRepository(repo, token string) {
 	agent.procs.Install.Args[1] = repo
 	agent.token = token
 	agent.procs.Install.Args[2] = token
+	util.RedactAudit(token)
 }
 
 func (agent *RemoteAgent) Bootstrap() error {
@@ -0,0 +1,188 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Types of the entries of the audit log
+const (
+	AuditSSHCommand       = "ssh"
+	AuditSSHCopy          = "copy"
+	AuditContainerCommand = "container"
+	AuditAPICall          = "api"
+)
+
+// AuditEntry is a line of the audit log, recording a command run by iofogctl
+type AuditEntry struct {
+	Time   string `json:"time"`
+	Type   string `json:"type"`
+	Target string `json:"target"`
+	// Command is the command run, the file copied or the method and path of the API call
+	Command string `json:"command"`
+	// ExitStatus is the exit status of the command, or the HTTP status of the API call.
+	// It is -1 when there is none, e.g. when the connection failed, and Error tells why
+	ExitStatus int    `json:"exitStatus"`
+	Error      string `json:"error,omitempty"`
+}
+
+// Redacted secrets are replaced with this placeholder
+const redacted = "<redacted>"
+
+var (
+	auditMutex sync.Mutex
+	auditFile  *os.File
+	// Transport wrapped by the auditTransport of http.DefaultTransport, see wrapDefaultTransport
+	auditedTransport http.RoundTripper
+	// Secrets replaced in the entries, see RedactAudit
+	auditSecrets []string
+	// Matches the values of the variables and flags named like passwords, secrets and tokens, e.g. DB_PASSWORD=...
+	auditSecretRegex = regexp.MustCompile(`(?i)([a-z0-9_.-]*(?:password|passwd|secret|token)[a-z0-9_.-]*=|--?(?:password|secret|token)\s+)("[^"]*"|'[^']*'|[^\s"']+)`)
+)
+
+// SetAuditLog appends an entry to the file for each SSH command and copy, container command and HTTP request
+// of the process, as JSON lines, until CloseAuditLog is called. Nothing is recorded if the file is empty
+func SetAuditLog(filename string) (err error) {
+	if filename == "" {
+		return nil
+	}
+	if filename, err = FormatPath(filename); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filename, os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0600)
+	if err != nil {
+		return NewInputError("Could not open audit log " + filename + ": " + err.Error())
+	}
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+	if auditFile == nil {
+		wrapDefaultTransport()
+		OnExit(CloseAuditLog)
+	} else {
+		auditFile.Close()
+	}
+	auditFile = file
+	return nil
+}
+
+// wrapDefaultTransport records the HTTP requests sent with http.DefaultTransport, which the Controller client uses.
+// This is the only place the default transport is replaced. The requests are still sent by the original transport,
+// which CloseAuditLog restores
+func wrapDefaultTransport() {
+	auditedTransport = http.DefaultTransport
+	http.DefaultTransport = &auditTransport{next: auditedTransport}
+}
+
+// CloseAuditLog closes the audit log and restores http.DefaultTransport. SetAuditLog registers it with OnExit
+func CloseAuditLog() {
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+	if auditFile == nil {
+		return
+	}
+	http.DefaultTransport = auditedTransport
+	auditedTransport = nil
+	Log(auditFile.Close)
+	auditFile = nil
+}
+
+// RedactAudit replaces the secrets with a placeholder in the entries of the audit log, e.g. passwords passed as arguments
+func RedactAudit(secrets ...string) {
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+	for _, secret := range secrets {
+		if secret != "" {
+			auditSecrets = append(auditSecrets, secret)
+		}
+	}
+}
+
+// Audit records the entry in the audit log, with the error of the command if it failed
+func Audit(entry AuditEntry, err error) {
+	auditMutex.Lock()
+	defer auditMutex.Unlock()
+	if auditFile == nil {
+		return
+	}
+	entry.Time = time.Now().UTC().Format(time.RFC3339Nano)
+	entry.Command = redactAudit(entry.Command)
+	if err != nil {
+		entry.Error = redactAudit(err.Error())
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	if _, err := auditFile.Write(append(line, '\n')); err != nil {
+		PrintNotify("Could not write to the audit log: " + err.Error())
+	}
+}
+
+func redactAudit(text string) string {
+	for _, secret := range auditSecrets {
+		text = strings.ReplaceAll(text, secret, redacted)
+	}
+	return auditSecretRegex.ReplaceAllString(text, "${1}"+redacted)
+}
+
+// auditSSH records the command run by the SSH client, or the file it copied
+func (cl *SecureShellClient) auditSSH(entryType, cmd string, err error) {
+	entry := AuditEntry{
+		Type:    entryType,
+		Target:  fmt.Sprintf("%s@%s:%d", cl.user, cl.host, cl.port),
+		Command: cmd,
+	}
+	var exitErr *ssh.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		entry.ExitStatus = exitErr.ExitStatus()
+		// The output of the command is not recorded
+		err = nil
+	default:
+		entry.ExitStatus = -1
+	}
+	Audit(entry, err)
+}
+
+// auditTransport records the HTTP requests sent through the next transport
+type auditTransport struct {
+	next http.RoundTripper
+}
+
+func (transport *auditTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	response, err := transport.next.RoundTrip(request)
+	entry := AuditEntry{
+		Type:       AuditAPICall,
+		Target:     request.URL.Host,
+		Command:    request.Method + " " + request.URL.Path,
+		ExitStatus: -1,
+	}
+	if response != nil {
+		entry.ExitStatus = response.StatusCode
+	}
+	Audit(entry, err)
+	return response, err
+}
@@ -0,0 +1,111 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"bufio"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// readAuditLog closes the audit log and returns its entries
+func readAuditLog(t *testing.T, filename string) (entries []AuditEntry) {
+	CloseAuditLog()
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			t.Fatalf("Expected a JSON line, got %s: %v", scanner.Text(), err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// countingTransport counts the requests it sends
+type countingTransport struct {
+	next     http.RoundTripper
+	requests int
+}
+
+func (transport *countingTransport) RoundTrip(request *http.Request) (*http.Response, error) {
+	transport.requests++
+	return transport.next.RoundTrip(request)
+}
+
+func TestAuditLog(t *testing.T) {
+	transport := &countingTransport{next: http.DefaultTransport}
+	http.DefaultTransport = transport
+	defer func() { http.DefaultTransport = transport.next }()
+	filename := filepath.Join(t.TempDir(), "audit.log")
+	if err := SetAuditLog(filename); err != nil {
+		t.Fatal(err)
+	}
+	RedactAudit("s3cr3t-token")
+
+	server := newTestServer(t, &ssh.ServerConfig{NoClientAuth: true})
+	t.Setenv("SSH_PASSWORD", "")
+	cl := server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+	if err := cl.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.Run(`sudo set_env.sh "DB_PASSWORD=hunter2" "DB_USER=iofog" && install.sh 3.0.0 repo s3cr3t-token`); err != nil {
+		t.Fatal(err)
+	}
+	cl.Disconnect()
+	CloseSSHConnections()
+
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer api.Close()
+	response, err := (&http.Client{}).Get(api.URL + "/api/v3/agent/list?token=abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	response.Body.Close()
+
+	entries := readAuditLog(t, filename)
+	if transport.requests != 1 {
+		t.Errorf("Expected the request to be sent by the original transport, got %d requests", transport.requests)
+	}
+	if http.DefaultTransport != transport {
+		t.Error("Expected the original transport to be restored once the audit log is closed")
+	}
+	if len(entries) != 2 {
+		t.Fatalf("Expected 2 entries, got %v", entries)
+	}
+	expected := `sudo set_env.sh "DB_PASSWORD=<redacted>" "DB_USER=iofog" && install.sh 3.0.0 repo <redacted>`
+	if entries[0].Type != AuditSSHCommand || entries[0].Command != expected || entries[0].ExitStatus != 0 || entries[0].Target != cl.user+"@"+server.host+":"+strconv.Itoa(server.port) {
+		t.Errorf("Expected the SSH command with its secrets redacted, got %+v", entries[0])
+	}
+	if entries[1].Type != AuditAPICall || entries[1].Command != "GET /api/v3/agent/list" || entries[1].ExitStatus != http.StatusNotFound {
+		t.Errorf("Expected the API call with its HTTP status, got %+v", entries[1])
+	}
+	if entries[0].Time == "" {
+		t.Error("Expected the entries to be timestamped")
+	}
+}
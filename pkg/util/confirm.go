@@ -0,0 +1,84 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"golang.org/x/term"
+)
+
+// Confirm asks the user a yes/no question on stdin. Any answer other than y or yes is a no
+func Confirm(message string) (bool, error) {
+	wasRunning := SpinPause()
+	defer func() {
+		if wasRunning {
+			SpinUnpause()
+		}
+	}()
+	fmt.Printf("%s [y/N]: ", message)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return false, err
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
+
+var (
+	secretsMutex sync.Mutex
+	// Secrets prompted for, by key
+	secrets = make(map[string]string)
+)
+
+// PromptSecret asks the user for a secret on the terminal without echoing it.
+// The secret of a key is only prompted for once per process
+func PromptSecret(key, message string) (string, error) {
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+	if secret, found := secrets[key]; found {
+		return secret, nil
+	}
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return "", NewInputError(fmt.Sprintf("Cannot prompt to %s, stdin is not a terminal", strings.ToLower(strings.TrimSuffix(message, ": "))))
+	}
+
+	wasRunning := SpinPause()
+	defer func() {
+		if wasRunning {
+			SpinUnpause()
+		}
+	}()
+	fmt.Print(message)
+	secret, err := term.ReadPassword(fd)
+	fmt.Println()
+	if err != nil {
+		return "", err
+	}
+	secrets[key] = string(secret)
+	return string(secret), nil
+}
+
+// forgetSecret prompts for the secret of the key again, e.g. once it turned out to be wrong
+func forgetSecret(key string) {
+	secretsMutex.Lock()
+	defer secretsMutex.Unlock()
+	delete(secrets, key)
+}
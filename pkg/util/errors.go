@@ -19,14 +19,30 @@ import (
 	"strings"
 )
 
+// Functions to run before exiting on error
+var exitHandlers []func()
+
+// OnExit registers a function to run before Check or Exit exit the process
+func OnExit(handler func()) {
+	exitHandlers = append(exitHandlers, handler)
+}
+
 // Check error and exit
 func Check(err error) {
 	if err != nil {
 		PrintError(err.Error())
-		os.Exit(1)
+		Exit(1)
 	}
 }
 
+// Exit runs the exit handlers and exits the process with the code
+func Exit(code int) {
+	for _, handler := range exitHandlers {
+		handler()
+	}
+	os.Exit(code)
+}
+
 func Log(callback func() error) {
 	err := callback()
 	if err != nil {
@@ -176,3 +192,24 @@ func IsUnsupportedAPIError(err error) bool {
 	apiErr := NewUnsupportedAPIError("")
 	return err != nil && strings.Contains(err.Error(), apiErr.header)
 }
+
+// GetErrorMessage returns the message of an error without its header
+func GetErrorMessage(err error) string {
+	switch err := err.(type) {
+	case *NotFoundError:
+		return err.message
+	case *ConflictError:
+		return err.message
+	case *InputError:
+		return err.message
+	case *InternalError:
+		return err.message
+	case *HTTPError:
+		return err.message
+	case *UnmarshalError:
+		return err.message
+	case *UnsupportedAPIError:
+		return err.message
+	}
+	return err.Error()
+}
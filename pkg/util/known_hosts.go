@@ -0,0 +1,186 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/knownhosts"
+)
+
+// HostKeyChecking is the verification of the host keys of SSH servers which are not pinned
+type HostKeyChecking string
+
+const (
+	// StrictHostKeyChecking rejects the hosts whose key is not in the known_hosts file
+	StrictHostKeyChecking HostKeyChecking = "strict"
+	// AcceptNewHostKeyChecking trusts the hosts on first use, adding their key to the known_hosts file
+	AcceptNewHostKeyChecking HostKeyChecking = "accept-new"
+	// NoHostKeyChecking accepts any host key
+	NoHostKeyChecking HostKeyChecking = "off"
+)
+
+var (
+	hostKeyChecking = StrictHostKeyChecking
+	knownHostsFile  string
+	// Serializes the additions to the known_hosts file
+	knownHostsMutex sync.Mutex
+)
+
+// SetHostKeyChecking sets how host keys are verified and the known_hosts file used to verify them.
+// ~/.ssh/known_hosts is used if the file is empty
+func SetHostKeyChecking(checking HostKeyChecking, filename string) error {
+	switch checking {
+	case StrictHostKeyChecking, AcceptNewHostKeyChecking, NoHostKeyChecking:
+	default:
+		return NewInputError(fmt.Sprintf("Unknown host key checking %s, expected %s, %s or %s", checking, StrictHostKeyChecking, AcceptNewHostKeyChecking, NoHostKeyChecking))
+	}
+	if filename == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return err
+		}
+		filename = filepath.Join(home, ".ssh", "known_hosts")
+	}
+	var err error
+	if knownHostsFile, err = FormatPath(filename); err != nil {
+		return err
+	}
+	hostKeyChecking = checking
+	return nil
+}
+
+// getHostKeyCallback verifies the host key against the pinned key, or against the known_hosts file
+func getHostKeyCallback(pinnedKey string) (ssh.HostKeyCallback, error) {
+	if pinnedKey != "" {
+		return newPinnedHostKeyCallback(pinnedKey)
+	}
+	if hostKeyChecking == NoHostKeyChecking {
+		return ssh.InsecureIgnoreHostKey(), nil
+	}
+	if knownHostsFile == "" {
+		if err := SetHostKeyChecking(hostKeyChecking, ""); err != nil {
+			return nil, err
+		}
+	}
+	return newKnownHostsCallback(knownHostsFile, hostKeyChecking == AcceptNewHostKeyChecking), nil
+}
+
+// getHostKeyIdentity identifies how the host key is verified, by the fingerprint of the pinned key or by the known_hosts file and checking
+func getHostKeyIdentity(pinnedKey string) string {
+	if pinnedKey == "" {
+		return fmt.Sprintf("known_hosts %s %s", hostKeyChecking, knownHostsFile)
+	}
+	if fingerprint, err := ParseHostKey(pinnedKey); err == nil {
+		return "pinned " + fingerprint
+	}
+	return "pinned " + pinnedKey
+}
+
+// ParseHostKey checks a pinned host key, either a public key in authorized_keys format or a SHA256 fingerprint
+func ParseHostKey(hostKey string) (fingerprint string, err error) {
+	if strings.HasPrefix(hostKey, "SHA256:") {
+		return hostKey, nil
+	}
+	key, _, _, _, err := ssh.ParseAuthorizedKey([]byte(hostKey))
+	if err != nil {
+		return "", NewInputError(fmt.Sprintf("Invalid host key %s, expected a public key such as ssh-ed25519 AAAA... or a SHA256 fingerprint: %s", hostKey, err.Error()))
+	}
+	return ssh.FingerprintSHA256(key), nil
+}
+
+func newPinnedHostKeyCallback(pinnedKey string) (ssh.HostKeyCallback, error) {
+	fingerprint, err := ParseHostKey(pinnedKey)
+	if err != nil {
+		return nil, err
+	}
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		SSHVerbose(fmt.Sprintf("Verifying host key %s against pinned key %s", ssh.FingerprintSHA256(key), fingerprint))
+		if ssh.FingerprintSHA256(key) != fingerprint {
+			msg := "The %s host key of %s does not match its pinned key.\nExpected fingerprint %s, got %s. The host may have been reinstalled, or the connection intercepted. Update the hostKey of its SSH configuration if the change is expected"
+			return NewError(fmt.Sprintf(msg, key.Type(), hostname, fingerprint, ssh.FingerprintSHA256(key)))
+		}
+		return nil
+	}, nil
+}
+
+func newKnownHostsCallback(filename string, acceptNew bool) ssh.HostKeyCallback {
+	return func(hostname string, remote net.Addr, key ssh.PublicKey) error {
+		knownHostsMutex.Lock()
+		defer knownHostsMutex.Unlock()
+
+		SSHVerbose(fmt.Sprintf("Verifying host key %s with %s", ssh.FingerprintSHA256(key), filename))
+		err := checkKnownHosts(filename, hostname, remote, key)
+		var keyErr *knownhosts.KeyError
+		if !errors.As(err, &keyErr) {
+			return err
+		}
+		if len(keyErr.Want) > 0 {
+			known := keyErr.Want[0]
+			msg := "The %s host key of %s has changed since it was added to %s:%d.\nExpected fingerprint %s, got %s. The host may have been reinstalled, or the connection intercepted. Remove its key from %s if the change is expected"
+			return NewError(fmt.Sprintf(msg, key.Type(), hostname, known.Filename, known.Line, ssh.FingerprintSHA256(known.Key), ssh.FingerprintSHA256(key), known.Filename))
+		}
+		if !acceptNew {
+			msg := "The %s host key of %s is unknown, its fingerprint is %s.\nAdd it to %s, pin it with the hostKey of its SSH configuration, or use --host-key-checking %s to trust it on first use"
+			return NewError(fmt.Sprintf(msg, key.Type(), hostname, ssh.FingerprintSHA256(key), filename, AcceptNewHostKeyChecking))
+		}
+		return addKnownHost(filename, hostname, key)
+	}
+}
+
+// checkKnownHosts reads the file on each check to find the keys added by other connections
+func checkKnownHosts(filename, hostname string, remote net.Addr, key ssh.PublicKey) error {
+	if _, err := os.Stat(filename); errors.Is(err, os.ErrNotExist) {
+		return &knownhosts.KeyError{}
+	}
+	callback, err := knownhosts.New(filename)
+	if err != nil {
+		return err
+	}
+	return callback(hostname, remote, key)
+}
+
+func addKnownHost(filename, hostname string, key ssh.PublicKey) error {
+	PrintNotify(fmt.Sprintf("Adding the %s host key of %s to %s, its fingerprint is %s", key.Type(), hostname, filename, ssh.FingerprintSHA256(key)))
+	data, err := os.ReadFile(filename)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	line := knownhosts.Line([]string{knownhosts.Normalize(hostname)}, key) + "\n"
+	// Keep the last line intact if it does not end with a new line
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		line = "\n" + line
+	}
+
+	if err := os.MkdirAll(filepath.Dir(filename), 0700); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err := file.WriteString(line); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
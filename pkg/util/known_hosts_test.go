@@ -0,0 +1,95 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func newHostKey(t *testing.T) ssh.PublicKey {
+	pub, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := ssh.NewPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestPinnedHostKey(t *testing.T) {
+	key := newHostKey(t)
+	other := newHostKey(t)
+	addr := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 22}
+	for _, pinned := range []string{string(ssh.MarshalAuthorizedKey(key)), ssh.FingerprintSHA256(key)} {
+		callback, err := newPinnedHostKeyCallback(strings.TrimSpace(pinned))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := callback("edge:22", addr, key); err != nil {
+			t.Errorf("Expected pinned key %s to be accepted, got %v", pinned, err)
+		}
+		if err := callback("edge:22", addr, other); err == nil || !strings.Contains(err.Error(), "does not match its pinned key") {
+			t.Errorf("Expected another key to be rejected by pinned key %s, got %v", pinned, err)
+		}
+	}
+	if _, err := ParseHostKey("ssh-ed25519 invalid"); err == nil {
+		t.Error("Expected an invalid host key to be rejected")
+	}
+}
+
+func TestKnownHosts(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "ssh", "known_hosts")
+	key := newHostKey(t)
+	other := newHostKey(t)
+	addr := &net.TCPAddr{IP: net.IPv4(10, 0, 0, 1), Port: 2222}
+
+	// Unknown hosts are rejected in strict mode
+	strict := newKnownHostsCallback(filename, false)
+	if err := strict("edge:2222", addr, key); err == nil || !strings.Contains(err.Error(), "is unknown") {
+		t.Errorf("Expected an unknown host to be rejected, got %v", err)
+	}
+
+	// Unknown hosts are added on first use
+	acceptNew := newKnownHostsCallback(filename, true)
+	if err := acceptNew("edge:2222", addr, key); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "[edge]:2222 ssh-ed25519 ") {
+		t.Errorf("Unexpected known_hosts file %s", data)
+	}
+	if err := strict("edge:2222", addr, key); err != nil {
+		t.Errorf("Expected the added host to be known, got %v", err)
+	}
+
+	// Changed keys are rejected in every mode
+	for _, callback := range []ssh.HostKeyCallback{strict, acceptNew} {
+		if err := callback("edge:2222", addr, other); err == nil || !strings.Contains(err.Error(), "has changed") {
+			t.Errorf("Expected a changed key to be rejected, got %v", err)
+		}
+	}
+}
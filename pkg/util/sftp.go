@@ -0,0 +1,271 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/pkg/sftp"
+)
+
+// Matches the output of sha256sum and shasum -a 256
+var sha256OutputRegex = regexp.MustCompile(`^([0-9a-f]{64})\s`)
+
+// Delay between the progress updates of a copy
+const copyProgressInterval = 250 * time.Millisecond
+
+// getSFTPClient returns the SFTP client of the connection, which is opened on first use.
+// Its session counts against the sessions of the connection until the client disconnects. As SFTP sessions
+// are limited to maxSFTPSessions, the commands run while copying always have sessions left
+func (cl *SecureShellClient) getSFTPClient() (*sftp.Client, error) {
+	if cl.sftp != nil {
+		return cl.sftp, nil
+	}
+	if cl.conn == nil {
+		return nil, NewInternalError("SSH client is not connected")
+	}
+	if cl.pooled != nil {
+		select {
+		case cl.pooled.sftpSessions <- struct{}{}:
+		case <-cl.ctx.Done():
+			return nil, cl.ctx.Err()
+		}
+	}
+	session, err := cl.newSession()
+	if err != nil {
+		cl.releaseSFTPSession()
+		return nil, err
+	}
+	stdin, err := session.StdinPipe()
+	if err == nil {
+		var stdout io.Reader
+		if stdout, err = session.StdoutPipe(); err == nil {
+			if err = session.RequestSubsystem("sftp"); err == nil {
+				cl.sftp, err = sftp.NewClientPipe(stdout, stdin)
+			}
+		}
+	}
+	if err != nil {
+		cl.closeSession(session)
+		cl.releaseSFTPSession()
+		return nil, err
+	}
+	cl.sftpSession = session
+	return cl.sftp, nil
+}
+
+func (cl *SecureShellClient) closeSFTPClient() {
+	if cl.sftp == nil {
+		return
+	}
+	Log(cl.sftp.Close)
+	cl.closeSession(cl.sftpSession)
+	cl.releaseSFTPSession()
+	cl.sftp = nil
+	cl.sftpSession = nil
+}
+
+func (cl *SecureShellClient) releaseSFTPSession() {
+	if cl.pooled != nil {
+		<-cl.pooled.sftpSessions
+	}
+}
+
+// sftpCopyTo copies the file through SFTP and verifies its SHA-256 checksum once copied.
+// The file is written to a .part file renamed once verified, so a copy which is interrupted is resumed by the next copy.
+// When the reader can seek, the files whose checksum is unchanged are not copied again
+func (cl *SecureShellClient) sftpCopyTo(client *sftp.Client, reader io.Reader, destPath, destFilename, permissions string, size int64) (err error) {
+	mode, err := strconv.ParseUint(permissions, 8, 32)
+	if err != nil {
+		return NewError("Invalid file permission specified: " + permissions)
+	}
+	dest := JoinAgentPath(destPath, destFilename)
+	part := JoinAgentPath(destPath, "."+destFilename+".part")
+
+	// Skip unchanged files
+	var localSum string
+	seeker, seekable := reader.(io.ReadSeeker)
+	if seekable {
+		if localSum, err = hashPrefix(seeker, size); err != nil {
+			return err
+		}
+		if info, statErr := client.Stat(dest); statErr == nil && info.Size() == size {
+			if remoteSum, sumErr := cl.getRemoteSHA256(client, dest); sumErr == nil && remoteSum == localSum {
+				SSHVerbose(fmt.Sprintf("Skipping unchanged file %s", dest))
+				return client.Chmod(dest, os.FileMode(mode))
+			}
+		}
+	}
+
+	// Resume the partial copy if its content is the beginning of the file
+	offset := int64(0)
+	if seekable {
+		if info, statErr := client.Stat(part); statErr == nil && info.Size() > 0 && info.Size() <= size {
+			prefixSum, err := hashPrefix(seeker, info.Size())
+			if err != nil {
+				return err
+			}
+			if remoteSum, sumErr := cl.getRemoteSHA256(client, part); sumErr == nil && remoteSum == prefixSum {
+				SSHVerbose(fmt.Sprintf("Resuming copy of %s at %d bytes", dest, info.Size()))
+				offset = info.Size()
+			}
+		}
+		if _, err = seeker.Seek(offset, io.SeekStart); err != nil {
+			return err
+		}
+	}
+
+	// Copy the rest of the file
+	flags := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+	if offset > 0 {
+		flags = os.O_WRONLY
+	}
+	file, err := client.OpenFile(part, flags)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Seek(offset, io.SeekStart); err != nil {
+		file.Close()
+		return err
+	}
+	progress := &copyProgress{reader: reader, name: destFilename, copied: offset, size: size}
+	if !seekable {
+		progress.hash = sha256.New()
+	}
+	copied, err := io.Copy(file, progress)
+	SpinProgress("")
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		if ctxErr := cl.ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		return NewError(fmt.Sprintf("Could not copy %s, copying it again resumes the copy\n%s", dest, err.Error()))
+	}
+	if offset+copied != size {
+		Log(func() error { return client.Remove(part) })
+		return NewError(fmt.Sprintf("Could not copy %s, copied %d bytes instead of %d", dest, offset+copied, size))
+	}
+
+	// Verify the copy
+	if progress.hash != nil {
+		localSum = hex.EncodeToString(progress.hash.Sum(nil))
+	}
+	remoteSum, err := cl.getRemoteSHA256(client, part)
+	if err != nil {
+		return err
+	}
+	if remoteSum != localSum {
+		Log(func() error { return client.Remove(part) })
+		return NewError(fmt.Sprintf("Checksum of %s is %s instead of %s, the file was corrupted during the copy", dest, remoteSum, localSum))
+	}
+	SSHVerbose(fmt.Sprintf("Verified SHA-256 %s of %s", remoteSum, dest))
+
+	if err = client.Chmod(part, os.FileMode(mode)); err != nil {
+		return err
+	}
+	if err = client.PosixRename(part, dest); err != nil {
+		// Servers without the posix-rename extension do not replace existing files
+		_ = client.Remove(dest)
+		return client.Rename(part, dest)
+	}
+	return nil
+}
+
+// hashPrefix returns the SHA-256 checksum of the first size bytes of the reader
+func hashPrefix(reader io.ReadSeeker, size int64) (string, error) {
+	if _, err := reader.Seek(0, io.SeekStart); err != nil {
+		return "", err
+	}
+	sum := sha256.New()
+	if _, err := io.CopyN(sum, reader, size); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// getRemoteSHA256 returns the SHA-256 checksum of a file of the host, computed by the host if it can,
+// otherwise by reading the file
+func (cl *SecureShellClient) getRemoteSHA256(client *sftp.Client, filename string) (string, error) {
+	quoted := shellQuote(filename)
+	stdout, err := cl.Run(fmt.Sprintf("sha256sum %s 2>/dev/null || shasum -a 256 %s", quoted, quoted))
+	if err == nil {
+		if match := sha256OutputRegex.FindStringSubmatch(stdout.String()); match != nil {
+			return match[1], nil
+		}
+	}
+	if ctxErr := cl.ctx.Err(); ctxErr != nil {
+		return "", ctxErr
+	}
+
+	SSHVerbose(fmt.Sprintf("Reading %s to compute its checksum", filename))
+	file, err := client.Open(filename)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	sum := sha256.New()
+	if _, err := file.WriteTo(sum); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// copyProgress reports the progress of a copy through the spinner, and hashes the copied content if hash is set
+type copyProgress struct {
+	reader     io.Reader
+	hash       hash.Hash
+	name       string
+	copied     int64
+	size       int64
+	lastUpdate time.Time
+}
+
+func (progress *copyProgress) Read(buf []byte) (int, error) {
+	n, err := progress.reader.Read(buf)
+	if n > 0 {
+		if progress.hash != nil {
+			_, _ = progress.hash.Write(buf[:n])
+		}
+		progress.copied += int64(n)
+		if time.Since(progress.lastUpdate) >= copyProgressInterval && progress.size > 0 {
+			progress.lastUpdate = time.Now()
+			SpinProgress(fmt.Sprintf("(copying %s %d%%, %s of %s)", progress.name, progress.copied*100/progress.size, formatBytes(progress.copied), formatBytes(progress.size)))
+		}
+	}
+	return n, err
+}
+
+// formatBytes returns the size in B, KB, MB or GB
+func formatBytes(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	value, units := float64(size)/unit, "KMGT"
+	idx := 0
+	for ; value >= unit && idx < len(units)-1; idx++ {
+		value /= unit
+	}
+	return fmt.Sprintf("%.1f %cB", value, units[idx])
+}
@@ -0,0 +1,149 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSFTPCopyTo(t *testing.T) {
+	server := newTestServer(t, &ssh.ServerConfig{NoClientAuth: true})
+	t.Setenv("SSH_PASSWORD", "unused")
+	defer CloseSSHConnections()
+	cl := server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+	if err := cl.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer cl.Disconnect()
+
+	content := make([]byte, 1<<20)
+	if _, err := rand.Read(content); err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	dest := filepath.Join(dir, "volume.bin")
+	part := filepath.Join(dir, ".volume.bin.part")
+	checkCopy := func(msg string) {
+		t.Helper()
+		data, err := os.ReadFile(dest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, content) {
+			t.Errorf("%s: expected the copied file to be identical", msg)
+		}
+		info, err := os.Stat(dest)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Mode().Perm() != 0640 {
+			t.Errorf("%s: expected permissions 0640, got %o", msg, info.Mode().Perm())
+		}
+		if _, err := os.Stat(part); !os.IsNotExist(err) {
+			t.Errorf("%s: expected the partial file to be removed, got %v", msg, err)
+		}
+	}
+
+	// Readers which cannot seek are hashed while copied
+	if err := cl.CopyTo(io.LimitReader(bytes.NewReader(content), int64(len(content))), dir, "volume.bin", "0640", int64(len(content))); err != nil {
+		t.Fatal(err)
+	}
+	checkCopy("Copy")
+
+	// Unchanged files are not copied again
+	past := time.Now().Add(-time.Hour).Truncate(time.Second)
+	if err := os.Chtimes(dest, past, past); err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.CopyTo(bytes.NewReader(content), dir, "volume.bin", "0640", int64(len(content))); err != nil {
+		t.Fatal(err)
+	}
+	checkCopy("Unchanged copy")
+	if info, _ := os.Stat(dest); !info.ModTime().Equal(past) {
+		t.Errorf("Expected the unchanged file not to be copied again, modified at %s", info.ModTime())
+	}
+
+	// Partial copies are resumed, or restarted if their content differs
+	for _, partial := range [][]byte{content[:len(content)/3], []byte("corrupted")} {
+		if err := os.Remove(dest); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(part, partial, 0600); err != nil {
+			t.Fatal(err)
+		}
+		if err := cl.CopyTo(bytes.NewReader(content), dir, "volume.bin", "0640", int64(len(content))); err != nil {
+			t.Fatal(err)
+		}
+		checkCopy("Resumed copy")
+	}
+
+	// The size must match the content
+	if err := cl.CopyTo(bytes.NewReader(content[:10]), dir, "short.bin", "0640", 20); err == nil {
+		t.Error("Expected a copy shorter than its size to fail")
+	}
+}
+
+func TestSFTPSessions(t *testing.T) {
+	server := newTestServer(t, &ssh.ServerConfig{NoClientAuth: true})
+	t.Setenv("SSH_PASSWORD", "unused")
+	defer CloseSSHConnections()
+	clients := []*SecureShellClient{}
+	for idx := 0; idx < maxSFTPSessions; idx++ {
+		cl := server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+		if err := cl.Connect(); err != nil {
+			t.Fatal(err)
+		}
+		defer cl.Disconnect()
+		if _, err := cl.getSFTPClient(); err != nil {
+			t.Fatal(err)
+		}
+		clients = append(clients, cl)
+	}
+
+	// The SFTP sessions count against the sessions of the connection until their client disconnects
+	pooled := clients[0].pooled
+	if len(pooled.sessions) != maxSFTPSessions || len(pooled.sftpSessions) != maxSFTPSessions {
+		t.Errorf("Expected %d sessions, got %d sessions and %d SFTP sessions", maxSFTPSessions, len(pooled.sessions), len(pooled.sftpSessions))
+	}
+
+	// Commands still run while the SFTP sessions are open, and further SFTP sessions wait for one to close
+	cl := server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	cl.SetContext(ctx)
+	if err := cl.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer cl.Disconnect()
+	if _, err := cl.Run("echo ok"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cl.getSFTPClient(); err != context.DeadlineExceeded {
+		t.Errorf("Expected the SFTP session to wait, got %v", err)
+	}
+
+	clients[0].Disconnect()
+	if len(pooled.sessions) != maxSFTPSessions-1 || len(pooled.sftpSessions) != maxSFTPSessions-1 {
+		t.Errorf("Expected the SFTP session to be released, got %d sessions and %d SFTP sessions", len(pooled.sessions), len(pooled.sftpSessions))
+	}
+}
@@ -46,6 +46,20 @@ func SpinUnpause() {
 	SpinStart(currentMessage)
 }
 
+// SpinProgress shows the progress of the current step after the message of the spinner, or only the message if it is empty
+func SpinProgress(progress string) {
+	if quiet || !isRunning {
+		return
+	}
+	suffix := " " + currentMessage
+	if progress != "" {
+		suffix += " " + progress
+	}
+	spin.Lock()
+	spin.Suffix = suffix
+	spin.Unlock()
+}
+
 func SpinStop() {
 	isRunning = false
 	if quiet {
@@ -15,10 +15,12 @@ package util
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"io"
 	"io/ioutil"
+	"net"
 	"os"
 	"path/filepath"
 	"regexp"
@@ -27,44 +29,62 @@ import (
 	"sync"
 	"time"
 
+	"github.com/pkg/sftp"
 	"golang.org/x/crypto/ssh"
 )
 
 type SecureShellClient struct {
-	user            string
-	host            string
-	port            int
-	privKeyFilename string
-	config          *ssh.ClientConfig
-	conn            *ssh.Client
+	user      string
+	host      string
+	port      int
+	auth      SSHAuth
+	hostKey   string
+	jumpHosts []SSHJumpHost
+	privilege SSHPrivilege
+	config    *ssh.ClientConfig
+	conn      *ssh.Client
+	agentConn net.Conn
+	ctx       context.Context
+	// Connection of the pool shared with the other clients of the host
+	pooled *sshConnection
+	// SFTP client and its session, opened by the first copy
+	sftp        *sftp.Client
+	sftpSession *ssh.Session
+	// Whether the settings were completed with the OpenSSH client configuration file
+	resolved bool
+	// Whether the user defaulted to the local user when completing the settings
+	localUser bool
 }
 
-func NewSecureShellClient(user, host, privKeyFilename string) (*SecureShellClient, error) {
+// SSHJumpHost is a host the connection is tunnelled through, like the ProxyJump option of OpenSSH
+type SSHJumpHost struct {
+	User    string
+	Host    string
+	Port    int
+	Auth    SSHAuth
+	HostKey string
+}
+
+// NewSecureShellClient returns a client of the host. The user, port, authentication and jump hosts which are not provided
+// are read from the entry of the host in ~/.ssh/config when connecting, the host can be one of its aliases
+func NewSecureShellClient(user, host string, auth SSHAuth) (*SecureShellClient, error) {
+	if host == "" {
+		return nil, NewInputError("No SSH host provided")
+	}
 	cl := &SecureShellClient{
-		user:            user,
-		host:            host,
-		port:            22,
-		privKeyFilename: privKeyFilename,
-	}
-	// Parse keys
-	SSHVerbose("Parsing keys")
-	key, err := cl.getPublicKey()
-	if err != nil {
-		return nil, err
+		user: user,
+		host: host,
+		auth: auth,
+		ctx:  context.Background(),
 	}
 
 	// Instantiate config
 	SSHVerbose("Configuring SSH client")
 	cl.config = &ssh.ClientConfig{
 		User: cl.user,
-		Auth: []ssh.AuthMethod{
-			key,
-		},
-		HostKeyCallback: ssh.InsecureIgnoreHostKey(),
 	}
 	SSHVerbose("Config:")
 	SSHVerbose(fmt.Sprintf("User: %s", cl.user))
-	SSHVerbose(fmt.Sprintf("Auth method: %v", key))
 	return cl, nil
 }
 
@@ -73,47 +93,268 @@ func (cl *SecureShellClient) SetPort(port int) {
 	cl.port = port
 }
 
+// SetHostKey pins the key of the host, see ParseHostKey. The known_hosts file is not used when a key is pinned
+func (cl *SecureShellClient) SetHostKey(hostKey string) {
+	SSHVerbose(fmt.Sprintf("Pinning host key %s", hostKey))
+	cl.hostKey = hostKey
+}
+
+// SetJumpHosts tunnels the connection through the jump hosts, in order
+func (cl *SecureShellClient) SetJumpHosts(jumpHosts []SSHJumpHost) {
+	for _, jumpHost := range jumpHosts {
+		SSHVerbose(fmt.Sprintf("Adding jump host %s@%s:%d", jumpHost.User, jumpHost.Host, jumpHost.Port))
+	}
+	cl.jumpHosts = jumpHosts
+}
+
+// SetContext makes the client abort connections and commands once the context is done
+func (cl *SecureShellClient) SetContext(ctx context.Context) {
+	cl.ctx = ctx
+}
+
 func (cl *SecureShellClient) Connect() (err error) {
 	// Don't bother connecting twice
 	SSHVerbose("Initialiasing connection")
 	if cl.conn != nil {
 		return nil
 	}
+	if err := cl.resolveConfig(SSHAuth{}); err != nil {
+		return err
+	}
+
+	// Share the connection of the other clients of the host
+	pooled, err := sshPool.acquire(cl.ctx, cl.getPoolKey(), cl.dialHost)
+	if err != nil {
+		return err
+	}
+	cl.pooled = pooled
+	cl.conn = pooled.client
+	return nil
+}
+
+// dialHost connects to the host through its jump hosts, and returns the connection and the clients of the jump hosts
+func (cl *SecureShellClient) dialHost() (*ssh.Client, []*SecureShellClient, error) {
+	// Each host is reached through the previous jump host
+	dialer := net.Dialer{}
+	dial := func(endpoint string) (net.Conn, error) {
+		SSHVerbose(fmt.Sprintf("TCP dialing %s", endpoint))
+		return dialer.DialContext(cl.ctx, "tcp", endpoint)
+	}
+	jumpClients := []*SecureShellClient{}
+	for _, jumpHost := range cl.jumpHosts {
+		jumpClient, err := NewSecureShellClient(jumpHost.User, jumpHost.Host, jumpHost.Auth)
+		if err != nil {
+			disconnectAll(jumpClients)
+			return nil, nil, err
+		}
+		jumpClient.SetPort(jumpHost.Port)
+		jumpClient.SetHostKey(jumpHost.HostKey)
+		jumpClient.SetContext(cl.ctx)
+		// Jump hosts without authentication method use the one of the host, and their own jump hosts are ignored
+		if err = jumpClient.resolveConfig(cl.auth); err == nil {
+			err = jumpClient.connect(dial)
+		}
+		if err != nil {
+			disconnectAll(jumpClients)
+			if ctxErr := cl.ctx.Err(); ctxErr != nil {
+				return nil, nil, ctxErr
+			}
+			return nil, nil, NewError(fmt.Sprintf("Could not connect to jump host %s@%s:%d\n%s", jumpClient.user, jumpClient.host, jumpClient.port, err.Error()))
+		}
+		jumpClients = append(jumpClients, jumpClient)
+		dial = jumpClient.dial
+	}
+
+	if err := cl.connect(dial); err != nil {
+		disconnectAll(jumpClients)
+		return nil, nil, err
+	}
+	return cl.conn, jumpClients, nil
+}
+
+// resolveConfig completes the settings which were not provided with the entry of the host in the OpenSSH client configuration file.
+// The user defaults to the local user, the port to 22 and the authentication to defaultAuth
+func (cl *SecureShellClient) resolveConfig(defaultAuth SSHAuth) error {
+	if cl.resolved {
+		return nil
+	}
+	alias := cl.host
+	hostConfig, err := getSSHHostConfig(alias)
+	if err != nil {
+		return err
+	}
+	if hostConfig.hostName != "" {
+		SSHVerbose(fmt.Sprintf("Resolved host %s to %s", alias, hostConfig.hostName))
+		cl.host = hostConfig.hostName
+	}
+	if cl.user == "" {
+		cl.user = hostConfig.user
+	}
+	if cl.user == "" {
+		cl.user = getLocalUser()
+		cl.localUser = true
+	}
+	cl.config.User = cl.user
+	if cl.port == 0 {
+		cl.port = hostConfig.port
+	}
+	if cl.port == 0 {
+		cl.port = 22
+	}
+	if cl.auth.IsEmpty() {
+		// As OpenSSH, the ssh-agent is used along with the identity file
+		cl.auth.KeyFile = hostConfig.getIdentityFile(alias, cl.host, cl.user)
+		cl.auth.Agent = os.Getenv("SSH_AUTH_SOCK") != ""
+	}
+	if cl.auth.IsEmpty() {
+		cl.auth = defaultAuth
+	}
+	if cl.auth.IsEmpty() {
+		return NewInputError(fmt.Sprintf("No SSH authentication method for %s@%s, provide a key file, the ssh-agent or a password, or an IdentityFile in ~/.ssh/config", cl.user, alias))
+	}
+	if len(cl.jumpHosts) == 0 && hostConfig.proxyJump != "" {
+		if cl.jumpHosts, err = parseProxyJump(hostConfig.proxyJump); err != nil {
+			return err
+		}
+	}
+	cl.resolved = true
+	return nil
+}
+
+// CheckConfig checks the settings of the client can be completed with the OpenSSH client configuration file
+// and the ssh-agent before connecting. Unlike when connecting, the user must be provided or found in the file
+// rather than default to the local user
+func (cl *SecureShellClient) CheckConfig() error {
+	if err := cl.resolveConfig(SSHAuth{}); err != nil {
+		return err
+	}
+	if cl.localUser {
+		return NewInputError(fmt.Sprintf("No SSH user for %s, provide a user or a User in ~/.ssh/config", cl.host))
+	}
+	return nil
+}
+
+// connect establishes the SSH connection over the connection to the endpoint of the host returned by dial
+func (cl *SecureShellClient) connect(dial func(endpoint string) (net.Conn, error)) (err error) {
+	// Verify the host key
+	if cl.config.HostKeyCallback, err = getHostKeyCallback(cl.hostKey); err != nil {
+		return err
+	}
+
+	// Authenticate
+	SSHVerbose("Preparing authentication methods")
+	if cl.config.Auth, err = cl.getAuthMethods(); err != nil {
+		cl.closeAgent()
+		return err
+	}
 
 	// Connect
 	endpoint := cl.host + ":" + strconv.Itoa(cl.port)
-	SSHVerbose(fmt.Sprintf("TCP dialing %s", endpoint))
-	cl.conn, err = ssh.Dial("tcp", endpoint, cl.config)
+	netConn, err := dial(endpoint)
+	if err != nil {
+		cl.closeAgent()
+		return err
+	}
+
+	// Abort the handshake if the context is done first
+	done := make(chan struct{})
+	go func() {
+		select {
+		case <-cl.ctx.Done():
+			netConn.Close()
+		case <-done:
+		}
+	}()
+	sshConn, chans, reqs, err := ssh.NewClientConn(netConn, endpoint, cl.config)
+	close(done)
+	// The ssh-agent is only needed to authenticate
+	cl.closeAgent()
 	if err != nil {
+		netConn.Close()
+		if ctxErr := cl.ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
 		return err
 	}
+	cl.conn = ssh.NewClient(sshConn, chans, reqs)
 
 	return nil
 }
 
+// dial opens a connection to the endpoint from the host
+func (cl *SecureShellClient) dial(endpoint string) (net.Conn, error) {
+	SSHVerbose(fmt.Sprintf("Dialing %s through %s", endpoint, cl.host))
+	return cl.conn.Dial("tcp", endpoint)
+}
+
+// disconnectAll disconnects the clients in reverse order
+func disconnectAll(clients []*SecureShellClient) {
+	for idx := len(clients) - 1; idx >= 0; idx-- {
+		Log(clients[idx].Disconnect)
+	}
+}
+
+// Disconnect releases the connection of the pool, which is closed once no client has used it for a while
 func (cl *SecureShellClient) Disconnect() error {
 	SSHVerbose("Disconnecting...")
+	cl.closeAgent()
+	cl.closeSFTPClient()
 	if cl.conn == nil {
 		return nil
 	}
 
-	err := cl.conn.Close()
-	if err != nil {
+	// The connections of jump hosts are owned by the connection tunnelled through them
+	if cl.pooled == nil {
+		err := cl.conn.Close()
+		cl.conn = nil
 		return err
 	}
-
-	SSHVerbose("Connection closed")
+	sshPool.release(cl.pooled)
+	cl.pooled = nil
 	cl.conn = nil
+	SSHVerbose("Connection released")
 	return nil
 }
 
+// newSession opens a session, waiting while the connection has as many sessions open as servers allow by default
+func (cl *SecureShellClient) newSession() (*ssh.Session, error) {
+	if cl.conn == nil {
+		return nil, NewInternalError("SSH client is not connected")
+	}
+	if cl.pooled != nil {
+		select {
+		case cl.pooled.sessions <- struct{}{}:
+		case <-cl.ctx.Done():
+			return nil, cl.ctx.Err()
+		}
+	}
+	session, err := cl.conn.NewSession()
+	if err != nil {
+		cl.releaseSession()
+		return nil, err
+	}
+	return session, nil
+}
+
+// closeSession closes the session opened by newSession
+func (cl *SecureShellClient) closeSession(session *ssh.Session) {
+	session.Close()
+	cl.releaseSession()
+}
+
+func (cl *SecureShellClient) releaseSession() {
+	if cl.pooled != nil {
+		<-cl.pooled.sessions
+	}
+}
+
 func (cl *SecureShellClient) Run(cmd string) (stdout bytes.Buffer, err error) {
 	// Establish the session
-	session, err := cl.conn.NewSession()
+	session, err := cl.newSession()
 	if err != nil {
 		return
 	}
-	defer session.Close()
+	defer cl.closeSession(session)
 
 	// Connect pipes
 	session.Stdout = &stdout
@@ -122,10 +363,16 @@ func (cl *SecureShellClient) Run(cmd string) (stdout bytes.Buffer, err error) {
 		err = format(err, nil, readToBuffer(stderr))
 		return
 	}
+	var escalated string
+	if escalated, err = cl.preparePrivilege(session, cmd, &stdout); err != nil {
+		return
+	}
 
 	// Run the command
-	SSHVerbose(fmt.Sprintf("Running: %s", cmd))
-	err = session.Run(cmd)
+	SSHVerbose(fmt.Sprintf("Running: %s", escalated))
+	err = cl.runSession(session, escalated)
+	cl.auditSSH(AuditSSHCommand, cmd, err)
+	cl.finishPrivilege(&stdout, err)
 	if err != nil {
 		err = format(err, &stdout, readToBuffer(stderr))
 		return
@@ -133,6 +380,28 @@ func (cl *SecureShellClient) Run(cmd string) (stdout bytes.Buffer, err error) {
 	return
 }
 
+// runSession runs the command, closing the session if the context is done first
+func (cl *SecureShellClient) runSession(session *ssh.Session, cmd string) error {
+	if err := cl.ctx.Err(); err != nil {
+		return err
+	}
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-cl.ctx.Done():
+			_ = session.Signal(ssh.SIGTERM)
+			session.Close()
+		case <-done:
+		}
+	}()
+	err := session.Run(cmd)
+	if ctxErr := cl.ctx.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
+}
+
 func format(err error, stdout, stderr fmt.Stringer) error {
 	if err == nil {
 		return nil
@@ -149,89 +418,97 @@ func format(err error, stdout, stderr fmt.Stringer) error {
 	return errors.New(msg)
 }
 
-func (cl *SecureShellClient) getPublicKey() (authMeth ssh.AuthMethod, err error) {
-	// Read priv key file, MUST BE RSA
-	SSHVerbose(fmt.Sprintf("Reading private key: %s", cl.privKeyFilename))
-	key, err := ioutil.ReadFile(cl.privKeyFilename)
-	if err != nil {
-		return
-	}
-
-	// Parse key
-	SSHVerbose("Parsing key")
-	signer, err := ssh.ParsePrivateKey(key)
-	if err != nil {
-		return
-	}
-
-	// Return pubkey obj
-	SSHVerbose("Creating auth method based on key pair")
-	authMeth = ssh.PublicKeys(signer)
-
-	return
-}
-
 func (cl *SecureShellClient) RunUntil(condition *regexp.Regexp, cmd string, ignoredErrors []string) (err error) {
 	// Retry until string condition matches
 	for iter := 0; iter < 30; iter++ {
 		SSHVerbose(fmt.Sprintf("Try %v", iter))
-		// Establish the session
-		var session *ssh.Session
-		session, err = cl.conn.NewSession()
-		if err != nil {
-			return
+		var matched bool
+		if matched, err = cl.runMatch(condition, cmd, ignoredErrors); err != nil || matched {
+			return err
 		}
-		defer session.Close()
-
-		// Connect pipes
-		var stderr io.Reader
-		stderr, err = session.StderrPipe()
-		if err != nil {
-			return
+		if err = Sleep(cl.ctx, 2*time.Second); err != nil {
+			return err
 		}
-		// Refresh stdout for every iter
-		stdoutBuffer := &bytes.Buffer{}
-		session.Stdout = stdoutBuffer
-
-		// Run the command
-		SSHVerbose(fmt.Sprintf("Running: %s", cmd))
-		err = session.Run(cmd)
-		// Ignore specified errors
-		if err != nil {
-			errMsg := err.Error()
-			for _, toIgnore := range ignoredErrors {
-				if strings.Contains(errMsg, toIgnore) {
-					// ignore error
-					SSHVerbose(fmt.Sprintf("Ignored error: %s", errMsg))
-					err = nil
-					break
-				}
+	}
+	return NewInternalError("Timed out waiting for condition '" + condition.String() + "' with SSH command: " + cmd)
+}
+
+// runMatch runs the command once and returns true if its output matches the condition
+func (cl *SecureShellClient) runMatch(condition *regexp.Regexp, cmd string, ignoredErrors []string) (bool, error) {
+	// Establish the session, which is closed before the next try
+	session, err := cl.newSession()
+	if err != nil {
+		return false, err
+	}
+	defer cl.closeSession(session)
+
+	// Connect pipes
+	stderr, err := session.StderrPipe()
+	if err != nil {
+		return false, err
+	}
+	// Refresh stdout for every iter
+	stdoutBuffer := &bytes.Buffer{}
+	session.Stdout = stdoutBuffer
+	escalated, err := cl.preparePrivilege(session, cmd, stdoutBuffer)
+	if err != nil {
+		return false, err
+	}
+
+	// Run the command
+	SSHVerbose(fmt.Sprintf("Running: %s", escalated))
+	err = cl.runSession(session, escalated)
+	cl.auditSSH(AuditSSHCommand, cmd, err)
+	cl.finishPrivilege(stdoutBuffer, err)
+	// Ignore specified errors
+	if err != nil {
+		errMsg := err.Error()
+		for _, toIgnore := range ignoredErrors {
+			if strings.Contains(errMsg, toIgnore) {
+				// ignore error
+				SSHVerbose(fmt.Sprintf("Ignored error: %s", errMsg))
+				err = nil
+				break
 			}
 		}
-		if err != nil {
-			return format(err, stdoutBuffer, readToBuffer(stderr))
-		}
-		if condition.MatchString(stdoutBuffer.String()) {
-			return nil
-		}
-		time.Sleep(2 * time.Second)
 	}
-	return NewInternalError("Timed out waiting for condition '" + condition.String() + "' with SSH command: " + cmd)
+	if err != nil {
+		return false, format(err, stdoutBuffer, readToBuffer(stderr))
+	}
+	return condition.MatchString(stdoutBuffer.String()), nil
 }
 
-func (cl *SecureShellClient) CopyTo(reader io.Reader, destPath, destFilename, permissions string, size int64) error {
+// CopyTo copies the size bytes of the reader to the file of the host with the permissions.
+// The file is copied with SFTP, see sftpCopyTo, or with scp if the host has no SFTP server
+func (cl *SecureShellClient) CopyTo(reader io.Reader, destPath, destFilename, permissions string, size int64) (err error) {
 	// Check permissions string
 	SSHVerbose(fmt.Sprintf("Copying file %s...", JoinAgentPath(destPath, destFilename)))
 	if !regexp.MustCompile(`\d{4}`).MatchString(permissions) {
 		return NewError("Invalid file permission specified: " + permissions)
 	}
+	defer func() {
+		cl.auditSSH(AuditSSHCopy, fmt.Sprintf("%s (%d bytes, %s)", JoinAgentPath(destPath, destFilename), size, permissions), err)
+	}()
+
+	sftpClient, err := cl.getSFTPClient()
+	if err != nil {
+		if ctxErr := cl.ctx.Err(); ctxErr != nil {
+			return ctxErr
+		}
+		SSHVerbose(fmt.Sprintf("Copying with scp, SFTP is not available: %s", err.Error()))
+		return cl.scpCopyTo(reader, destPath, destFilename, permissions, size)
+	}
+	return cl.sftpCopyTo(sftpClient, reader, destPath, destFilename, permissions, size)
+}
 
+// scpCopyTo copies the file with the scp protocol, which requires /usr/bin/scp on the host
+func (cl *SecureShellClient) scpCopyTo(reader io.Reader, destPath, destFilename, permissions string, size int64) error {
 	// Establish the session
-	session, err := cl.conn.NewSession()
+	session, err := cl.newSession()
 	if err != nil {
 		return err
 	}
-	defer session.Close()
+	defer cl.closeSession(session)
 
 	// Connect pipes
 	var stderr io.Reader
@@ -268,7 +545,7 @@ func (cl *SecureShellClient) CopyTo(reader io.Reader, destPath, destFilename, pe
 	// Start the scp command
 	cmd := "/usr/bin/scp -t "
 	SSHVerbose(fmt.Sprintf("Running: %s", cmd+destPath))
-	err = session.Run(cmd + destPath)
+	err = cl.runSession(session, cmd+destPath)
 
 	// Wait for completion
 	wg.Wait()
@@ -312,21 +589,24 @@ func (cl *SecureShellClient) CopyFolderTo(srcPath, destPath, permissions string,
 			); err != nil {
 				return err
 			}
-		} else {
-			// Read the file
-			openFile, err := os.Open(filepath.Join(srcPath, file.Name()))
-			if err != nil {
-				return err
-			}
-			// Copy the file
-			if err := cl.CopyTo(openFile, destPath, file.Name(), addLeadingZero(permissions), file.Size()); err != nil {
-				return err
-			}
+		} else if err := cl.copyFileTo(filepath.Join(srcPath, file.Name()), destPath, addLeadingZero(permissions), file.Size()); err != nil {
+			return err
 		}
 	}
 	return nil
 }
 
+func (cl *SecureShellClient) copyFileTo(srcFilename, destPath, permissions string, size int64) error {
+	// Read the file
+	openFile, err := os.Open(srcFilename)
+	if err != nil {
+		return err
+	}
+	defer openFile.Close()
+	// Copy the file
+	return cl.CopyTo(openFile, destPath, filepath.Base(srcFilename), permissions, size)
+}
+
 func (cl *SecureShellClient) CreateFolder(path string) error {
 	path = AddTrailingSlash(path)
 	SSHVerbose(fmt.Sprintf("Creating folder %s", path))
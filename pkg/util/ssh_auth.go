@@ -0,0 +1,173 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// SSHAuth holds the methods used to authenticate a SecureShellClient, tried in order: ssh-agent, key file, password
+type SSHAuth struct {
+	// KeyFile is a private key. The passphrase of an encrypted key is read from KeyPassphraseEnv, or prompted for if it is empty
+	KeyFile          string
+	KeyPassphraseEnv string
+	// Agent uses the keys of the ssh-agent listening on SSH_AUTH_SOCK, including those of hardware tokens
+	Agent bool
+	// PasswordAuth uses password and keyboard-interactive authentication.
+	// The password is read from PasswordEnv, or prompted for if it is empty
+	PasswordAuth bool
+	PasswordEnv  string
+}
+
+// IsEmpty returns true if there is no method to authenticate with
+func (auth SSHAuth) IsEmpty() bool {
+	return auth.KeyFile == "" && !auth.Agent && !auth.PasswordAuth && auth.PasswordEnv == ""
+}
+
+// getIdentity identifies the credentials of the methods: the ssh-agent socket, the content of the key file and the password
+func (auth SSHAuth) getIdentity() string {
+	identity := ""
+	if auth.Agent {
+		identity += fmt.Sprintf("agent %s\n", os.Getenv("SSH_AUTH_SOCK"))
+	}
+	if auth.KeyFile != "" {
+		// The content identifies the key even if it is encrypted
+		if key, err := os.ReadFile(auth.KeyFile); err == nil {
+			identity += fmt.Sprintf("key %x\n", sha256.Sum256(key))
+		} else {
+			identity += fmt.Sprintf("key %s\n", auth.KeyFile)
+		}
+	}
+	if auth.PasswordEnv != "" {
+		identity += fmt.Sprintf("password %x\n", sha256.Sum256([]byte(os.Getenv(auth.PasswordEnv))))
+	} else if auth.PasswordAuth {
+		// Prompted passwords are shared by the clients of the user@host
+		identity += "password\n"
+	}
+	return identity
+}
+
+func (cl *SecureShellClient) getAuthMethods() (methods []ssh.AuthMethod, err error) {
+	if cl.auth.Agent {
+		method, err := cl.getAgentAuth()
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, method)
+	}
+	if cl.auth.KeyFile != "" {
+		method, err := cl.getKeyAuth()
+		if err != nil {
+			return nil, err
+		}
+		methods = append(methods, method)
+	}
+	if cl.auth.PasswordAuth || cl.auth.PasswordEnv != "" {
+		methods = append(methods, cl.getPasswordAuth()...)
+	}
+	return methods, nil
+}
+
+func (cl *SecureShellClient) getAgentAuth() (ssh.AuthMethod, error) {
+	socket := os.Getenv("SSH_AUTH_SOCK")
+	if socket == "" {
+		return nil, NewInputError("Cannot authenticate with the ssh-agent, SSH_AUTH_SOCK is not set")
+	}
+	SSHVerbose(fmt.Sprintf("Connecting to ssh-agent %s", socket))
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, NewError(fmt.Sprintf("Could not connect to the ssh-agent %s: %s", socket, err.Error()))
+	}
+	cl.agentConn = conn
+	SSHVerbose("Creating auth method based on ssh-agent keys")
+	return ssh.PublicKeysCallback(agent.NewClient(conn).Signers), nil
+}
+
+func (cl *SecureShellClient) closeAgent() {
+	if cl.agentConn != nil {
+		cl.agentConn.Close()
+		cl.agentConn = nil
+	}
+}
+
+func (cl *SecureShellClient) getKeyAuth() (ssh.AuthMethod, error) {
+	SSHVerbose(fmt.Sprintf("Reading private key: %s", cl.auth.KeyFile))
+	key, err := os.ReadFile(cl.auth.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	SSHVerbose("Parsing key")
+	signer, err := ssh.ParsePrivateKey(key)
+	var missingErr *ssh.PassphraseMissingError
+	if errors.As(err, &missingErr) {
+		SSHVerbose("Decrypting key")
+		var passphrase string
+		if passphrase, err = cl.getSecret(cl.auth.KeyPassphraseEnv, "key:"+cl.auth.KeyFile, fmt.Sprintf("Enter passphrase for key %s: ", cl.auth.KeyFile)); err != nil {
+			return nil, err
+		}
+		if signer, err = ssh.ParsePrivateKeyWithPassphrase(key, []byte(passphrase)); err != nil {
+			forgetSecret("key:" + cl.auth.KeyFile)
+			return nil, NewInputError(fmt.Sprintf("Could not decrypt private key %s: %s", cl.auth.KeyFile, err.Error()))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	SSHVerbose("Creating auth method based on key pair")
+	return ssh.PublicKeys(signer), nil
+}
+
+// getPasswordAuth returns the password and keyboard-interactive methods, which only read the password if the server asks for it
+func (cl *SecureShellClient) getPasswordAuth() []ssh.AuthMethod {
+	getPassword := func() (string, error) {
+		return cl.getSecret(cl.auth.PasswordEnv, fmt.Sprintf("password:%s@%s", cl.user, cl.host), fmt.Sprintf("Enter password for %s@%s: ", cl.user, cl.host))
+	}
+	SSHVerbose("Creating auth methods based on password")
+	return []ssh.AuthMethod{
+		ssh.PasswordCallback(getPassword),
+		// Answer every question with the password
+		ssh.KeyboardInteractive(func(user, instruction string, questions []string, echos []bool) ([]string, error) {
+			answers := make([]string, len(questions))
+			for idx := range questions {
+				password, err := getPassword()
+				if err != nil {
+					return nil, err
+				}
+				answers[idx] = password
+			}
+			return answers, nil
+		}),
+	}
+}
+
+// getSecret reads the secret from the environment variable, or prompts for it once per key if the variable is empty
+func (cl *SecureShellClient) getSecret(env, key, prompt string) (string, error) {
+	if env != "" {
+		secret, found := os.LookupEnv(env)
+		if !found {
+			return "", NewInputError(fmt.Sprintf("Environment variable %s is not set", env))
+		}
+		return secret, nil
+	}
+	return PromptSecret(key, prompt)
+}
@@ -0,0 +1,277 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/user"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// sshConfigFile is the OpenSSH client configuration file, ~/.ssh/config when empty
+var sshConfigFile string
+
+// Maximum depth of the Include keyword, as OpenSSH
+const maxSSHConfigIncludeDepth = 16
+
+// sshHostConfig is the configuration of a host in the OpenSSH client configuration file
+type sshHostConfig struct {
+	hostName      string
+	user          string
+	port          int
+	identityFiles []string
+	proxyJump     string
+}
+
+// getSSHHostConfig returns the configuration of the host alias in the OpenSSH client configuration file.
+// As OpenSSH, the first value of each keyword wins, and Match blocks are ignored
+func getSSHHostConfig(alias string) (*sshHostConfig, error) {
+	hostConfig := &sshHostConfig{}
+	filename := sshConfigFile
+	if filename == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return hostConfig, nil
+		}
+		filename = filepath.Join(home, ".ssh", "config")
+	}
+	if _, err := os.Stat(filename); os.IsNotExist(err) {
+		return hostConfig, nil
+	}
+	parser := sshConfigParser{alias: alias, config: hostConfig, dir: filepath.Dir(filename)}
+	if err := parser.parse(filename, true, 0); err != nil {
+		return nil, err
+	}
+	return hostConfig, nil
+}
+
+type sshConfigParser struct {
+	alias  string
+	config *sshHostConfig
+	// Directory of the user configuration file, which relative Include paths are relative to
+	dir string
+}
+
+func (parser *sshConfigParser) parse(filename string, active bool, depth int) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		keyword, args := splitSSHConfigLine(scanner.Text())
+		if keyword == "" {
+			continue
+		}
+		invalid := func(msg string) error {
+			return NewInputError(fmt.Sprintf("Invalid %s at %s:%d: %s", keyword, filename, lineNumber, msg))
+		}
+		switch keyword {
+		case "host":
+			active = matchSSHHostPatterns(parser.alias, args)
+			continue
+		case "match":
+			active = false
+			continue
+		}
+		if !active {
+			continue
+		}
+		if len(args) == 0 {
+			return invalid("missing argument")
+		}
+		cfg := parser.config
+		switch keyword {
+		case "include":
+			if depth >= maxSSHConfigIncludeDepth {
+				return invalid("too many nested includes")
+			}
+			for _, pattern := range args {
+				pattern = expandSSHConfigHome(pattern)
+				if !filepath.IsAbs(pattern) {
+					pattern = filepath.Join(parser.dir, pattern)
+				}
+				matches, err := filepath.Glob(pattern)
+				if err != nil {
+					return invalid(err.Error())
+				}
+				for _, match := range matches {
+					if err := parser.parse(match, active, depth+1); err != nil {
+						return err
+					}
+				}
+			}
+		case "hostname":
+			if cfg.hostName == "" {
+				cfg.hostName = strings.ReplaceAll(args[0], "%h", parser.alias)
+			}
+		case "user":
+			if cfg.user == "" {
+				cfg.user = args[0]
+			}
+		case "port":
+			if cfg.port == 0 {
+				port, err := strconv.Atoi(args[0])
+				if err != nil || port <= 0 || port > 65535 {
+					return invalid(args[0])
+				}
+				cfg.port = port
+			}
+		case "identityfile":
+			cfg.identityFiles = append(cfg.identityFiles, args[0])
+		case "proxyjump":
+			if cfg.proxyJump == "" {
+				cfg.proxyJump = args[0]
+			}
+		}
+	}
+	return scanner.Err()
+}
+
+// splitSSHConfigLine returns the lowercase keyword and the arguments of a line, which are separated by spaces or an equal sign
+func splitSSHConfigLine(line string) (keyword string, args []string) {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return "", nil
+	}
+	end := strings.IndexAny(line, " \t=")
+	if end < 0 {
+		return strings.ToLower(line), nil
+	}
+	keyword = strings.ToLower(line[:end])
+	rest := strings.TrimLeft(line[end:], " \t")
+	rest = strings.TrimLeft(strings.TrimPrefix(rest, "="), " \t")
+
+	// Arguments can be quoted to contain spaces
+	for rest != "" {
+		var arg string
+		if rest[0] == '"' {
+			end = strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				arg, rest = rest[1:], ""
+			} else {
+				arg, rest = rest[1:end+1], rest[end+2:]
+			}
+		} else {
+			end = strings.IndexAny(rest, " \t")
+			if end < 0 {
+				arg, rest = rest, ""
+			} else {
+				arg, rest = rest[:end], rest[end:]
+			}
+		}
+		if strings.HasPrefix(arg, "#") {
+			break
+		}
+		args = append(args, arg)
+		rest = strings.TrimLeft(rest, " \t")
+	}
+	return keyword, args
+}
+
+// matchSSHHostPatterns returns true if the host matches one of the patterns and none of the negated patterns
+func matchSSHHostPatterns(host string, patterns []string) (matched bool) {
+	for _, pattern := range patterns {
+		for _, item := range strings.Split(pattern, ",") {
+			negated := strings.HasPrefix(item, "!")
+			item = strings.TrimPrefix(item, "!")
+			// Host names contain no slash, so the patterns of path.Match behave as OpenSSH patterns
+			if found, _ := path.Match(strings.ToLower(item), strings.ToLower(host)); found {
+				if negated {
+					return false
+				}
+				matched = true
+			}
+		}
+	}
+	return matched
+}
+
+// expandSSHConfigHome replaces a leading ~ by the home directory
+func expandSSHConfigHome(filename string) string {
+	if filename != "~" && !strings.HasPrefix(filename, "~/") {
+		return filename
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filename
+	}
+	return filepath.Join(home, filename[1:])
+}
+
+// getIdentityFile returns the first identity file which exists, with its tokens expanded
+func (hostConfig *sshHostConfig) getIdentityFile(alias, hostName, remoteUser string) string {
+	home, _ := os.UserHomeDir()
+	localUser := getLocalUser()
+	for _, identityFile := range hostConfig.identityFiles {
+		identityFile = strings.NewReplacer(
+			"%%", "%",
+			"%d", home,
+			"%u", localUser,
+			"%r", remoteUser,
+			"%h", hostName,
+			"%n", alias,
+		).Replace(identityFile)
+		identityFile = expandSSHConfigHome(identityFile)
+		if _, err := os.Stat(identityFile); err == nil {
+			return identityFile
+		}
+		SSHVerbose(fmt.Sprintf("Skipping missing identity file %s", identityFile))
+	}
+	return ""
+}
+
+// parseProxyJump returns the jump hosts of a ProxyJump value, a comma separated list of [user@]host[:port]
+func parseProxyJump(proxyJump string) (jumpHosts []SSHJumpHost, err error) {
+	if strings.EqualFold(proxyJump, "none") {
+		return nil, nil
+	}
+	for _, item := range strings.Split(proxyJump, ",") {
+		item = strings.TrimPrefix(item, "ssh://")
+		jumpHost := SSHJumpHost{Host: item}
+		if idx := strings.LastIndex(jumpHost.Host, "@"); idx >= 0 {
+			jumpHost.User, jumpHost.Host = jumpHost.Host[:idx], jumpHost.Host[idx+1:]
+		}
+		if idx := strings.LastIndex(jumpHost.Host, ":"); idx >= 0 && !strings.HasSuffix(jumpHost.Host, "]") {
+			port, err := strconv.Atoi(jumpHost.Host[idx+1:])
+			if err != nil {
+				return nil, NewInputError(fmt.Sprintf("Invalid port of ProxyJump %s", item))
+			}
+			jumpHost.Host, jumpHost.Port = jumpHost.Host[:idx], port
+		}
+		jumpHost.Host = strings.TrimSuffix(strings.TrimPrefix(jumpHost.Host, "["), "]")
+		if jumpHost.Host == "" {
+			return nil, NewInputError(fmt.Sprintf("Invalid ProxyJump %s", proxyJump))
+		}
+		jumpHosts = append(jumpHosts, jumpHost)
+	}
+	return jumpHosts, nil
+}
+
+// getLocalUser returns the name of the local user, the default user of SSH connections
+func getLocalUser() string {
+	if current, err := user.Current(); err == nil {
+		return current.Username
+	}
+	return os.Getenv("USER")
+}
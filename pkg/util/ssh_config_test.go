@@ -0,0 +1,207 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync/atomic"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// setSSHConfig makes the clients read the OpenSSH client configuration from a temporary file
+func setSSHConfig(t *testing.T, content string) string {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "config")
+	if err := os.WriteFile(filename, []byte(content), 0600); err != nil {
+		t.Fatal(err)
+	}
+	previous := sshConfigFile
+	sshConfigFile = filename
+	t.Cleanup(func() { sshConfigFile = previous })
+	return dir
+}
+
+func TestSSHHostConfig(t *testing.T) {
+	dir := setSSHConfig(t, `
+# Edge boxes
+Host edge-* !edge-test
+  HostName %h.example.com
+  User iofog
+  IdentityFile ~/.ssh/edge
+  IdentityFile ~/.ssh/id_rsa
+
+Match host edge-17
+  User ignored
+
+Host edge-17
+  Port 2222
+  User shadowed
+  ProxyJump admin@bastion:2200,gateway
+
+Include "extra config"
+
+Host *
+  Port=22
+  User default
+`)
+	if err := os.WriteFile(filepath.Join(dir, "extra config"), []byte("Host edge-17\n  ProxyJump shadowed\n  IdentityFile /keys/edge-17\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	hostConfig, err := getSSHHostConfig("edge-17")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &sshHostConfig{
+		hostName:      "edge-17.example.com",
+		user:          "iofog",
+		port:          2222,
+		identityFiles: []string{"~/.ssh/edge", "~/.ssh/id_rsa", "/keys/edge-17"},
+		proxyJump:     "admin@bastion:2200,gateway",
+	}
+	if !reflect.DeepEqual(hostConfig, expected) {
+		t.Errorf("Expected %+v, got %+v", expected, hostConfig)
+	}
+	if expandSSHConfigHome(hostConfig.identityFiles[0]) != filepath.Join(home, ".ssh", "edge") {
+		t.Errorf("Expected ~ to be expanded to the home directory, got %s", expandSSHConfigHome(hostConfig.identityFiles[0]))
+	}
+
+	hostConfig, err = getSSHHostConfig("edge-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(hostConfig, &sshHostConfig{user: "default", port: 22}) {
+		t.Errorf("Expected the negated host to only match Host *, got %+v", hostConfig)
+	}
+
+	jumpHosts, err := parseProxyJump(expected.proxyJump)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(jumpHosts, []SSHJumpHost{{User: "admin", Host: "bastion", Port: 2200}, {Host: "gateway"}}) {
+		t.Errorf("Unexpected jump hosts %+v", jumpHosts)
+	}
+	if _, err := parseProxyJump("bastion:ssh"); err == nil {
+		t.Error("Expected an invalid ProxyJump port to be rejected")
+	}
+}
+
+func TestSSHConfigConnect(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKeyServer := func(user string) *testServer {
+		return newTestServer(t, &ssh.ServerConfig{
+			PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+				if conn.User() == user && string(key.Marshal()) == string(signer.PublicKey().Marshal()) {
+					return nil, nil
+				}
+				return nil, ssh.ErrNoAuth
+			},
+		})
+	}
+	bastion := newKeyServer("admin")
+	edge := newKeyServer("iofog")
+
+	// The bastion has no identity file, so it uses the one of the edge host
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "edge")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	setSSHConfig(t, fmt.Sprintf(`
+Host bastion
+  HostName %s
+  Port %d
+  User admin
+
+Host edge-17
+  HostName %s
+  Port %d
+  User iofog
+  IdentityFile %s
+  ProxyJump bastion
+`, bastion.host, bastion.port, edge.host, edge.port, keyFile))
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	previousChecking, previousFile := hostKeyChecking, knownHostsFile
+	t.Cleanup(func() { hostKeyChecking, knownHostsFile = previousChecking, previousFile })
+	if err := SetHostKeyChecking(AcceptNewHostKeyChecking, filepath.Join(dir, "known_hosts")); err != nil {
+		t.Fatal(err)
+	}
+
+	cl, err := NewSecureShellClient("", "edge-17", SSHAuth{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	runEcho(t, cl)
+	if atomic.LoadInt32(&bastion.forwarded) != 1 {
+		t.Errorf("Expected the connection to go through the ProxyJump of the host, got %d forwards", bastion.forwarded)
+	}
+}
+
+func TestSSHCheckConfig(t *testing.T) {
+	keyFile := filepath.Join(t.TempDir(), "edge")
+	if err := os.WriteFile(keyFile, []byte("key"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	setSSHConfig(t, fmt.Sprintf("Host edge\n  User iofog\n  IdentityFile %s\n", keyFile))
+	t.Setenv("SSH_AUTH_SOCK", "")
+
+	check := func(user, host string, auth SSHAuth) error {
+		cl, err := NewSecureShellClient(user, host, auth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return cl.CheckConfig()
+	}
+	if err := check("", "edge", SSHAuth{}); err != nil {
+		t.Errorf("Expected the user and key of ~/.ssh/config to be used, got %v", err)
+	}
+	if err := check("", "other", SSHAuth{}); err == nil {
+		t.Error("Expected an error for a host without SSH details")
+	}
+	if err := check("", "other", SSHAuth{KeyFile: keyFile}); err == nil {
+		t.Error("Expected an error for a host without user")
+	}
+	if err := check("iofog", "other", SSHAuth{}); err == nil {
+		t.Error("Expected an error for a host without authentication")
+	}
+	t.Setenv("SSH_AUTH_SOCK", "/tmp/agent.sock")
+	if err := check("iofog", "other", SSHAuth{}); err != nil {
+		t.Errorf("Expected the ssh-agent to be used, got %v", err)
+	}
+}
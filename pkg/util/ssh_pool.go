@@ -0,0 +1,195 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"sync"
+	"time"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// Sessions opened at once on a connection, the default MaxSessions of OpenSSH servers
+const maxSSHSessions = 10
+
+// SFTP sessions opened at once on a connection. They stay open until their client disconnects,
+// so they are limited to leave sessions to the commands
+const maxSFTPSessions = maxSSHSessions / 2
+
+// sshIdleTimeout is how long a connection stays open once no client uses it
+var sshIdleTimeout = 30 * time.Second
+
+// sshConnection is a connection of the pool, shared by the clients of a user@host:port with the same settings, see getPoolKey
+type sshConnection struct {
+	key string
+	// Closed once the connection is established or failed
+	ready  chan struct{}
+	err    error
+	client *ssh.Client
+	// Clients of the jump hosts the connection is tunnelled through
+	jumpClients []*SecureShellClient
+	// Limits the sessions opened at once, including the SFTP sessions
+	sessions chan struct{}
+	// Limits the SFTP sessions opened at once
+	sftpSessions chan struct{}
+	// Number of clients using the connection
+	refs      int
+	idleTimer *time.Timer
+	closeOnce sync.Once
+}
+
+// close closes the connection, then the tunnel it goes through
+func (conn *sshConnection) close() {
+	conn.closeOnce.Do(func() {
+		SSHVerbose(fmt.Sprintf("Closing connection %s", conn.key))
+		if conn.client != nil {
+			conn.client.Close()
+		}
+		disconnectAll(conn.jumpClients)
+	})
+}
+
+// sshConnectionPool shares a connection between the clients of each user@host:port of the process
+type sshConnectionPool struct {
+	mutex sync.Mutex
+	conns map[string]*sshConnection
+}
+
+var sshPool = &sshConnectionPool{conns: make(map[string]*sshConnection)}
+
+// getPoolKey returns the key of the connection of the client in the pool. Clients only share a connection if they verify
+// the host key the same way, go through the same jump hosts in the same order and authenticate with the same credentials.
+// The settings are hashed to keep the credentials out of the key, which is logged
+func (cl *SecureShellClient) getPoolKey() string {
+	hash := sha256.New()
+	fmt.Fprintf(hash, "%s\n%s\n", getHostKeyIdentity(cl.hostKey), cl.auth.getIdentity())
+	for _, jumpHost := range cl.jumpHosts {
+		fmt.Fprintf(hash, "%s@%s:%d\n%s\n%s\n", jumpHost.User, jumpHost.Host, jumpHost.Port, getHostKeyIdentity(jumpHost.HostKey), jumpHost.Auth.getIdentity())
+	}
+	return fmt.Sprintf("%s@%s:%d#%x", cl.user, cl.host, cl.port, hash.Sum(nil)[:8])
+}
+
+// acquire returns the connection of the key, which is established with dial if the pool has none
+func (pool *sshConnectionPool) acquire(ctx context.Context, key string, dial func() (*ssh.Client, []*SecureShellClient, error)) (*sshConnection, error) {
+	pool.mutex.Lock()
+	conn, found := pool.conns[key]
+	if found {
+		conn.refs++
+		if conn.idleTimer != nil {
+			conn.idleTimer.Stop()
+			conn.idleTimer = nil
+		}
+		pool.mutex.Unlock()
+
+		// Wait for the client establishing the connection
+		select {
+		case <-conn.ready:
+		case <-ctx.Done():
+			pool.release(conn)
+			return nil, ctx.Err()
+		}
+		if conn.err != nil {
+			pool.release(conn)
+			return nil, conn.err
+		}
+		SSHVerbose(fmt.Sprintf("Reusing connection %s", key))
+		return conn, nil
+	}
+	conn = &sshConnection{
+		key:          key,
+		ready:        make(chan struct{}),
+		sessions:     make(chan struct{}, maxSSHSessions),
+		sftpSessions: make(chan struct{}, maxSFTPSessions),
+		refs:         1,
+	}
+	pool.conns[key] = conn
+	pool.mutex.Unlock()
+
+	conn.client, conn.jumpClients, conn.err = dial()
+	if conn.err != nil {
+		pool.remove(conn)
+		close(conn.ready)
+		return nil, conn.err
+	}
+	close(conn.ready)
+
+	// Connections closed by the server are not reused
+	go func() {
+		_ = conn.client.Wait()
+		pool.remove(conn)
+	}()
+	return conn, nil
+}
+
+// release closes the connection once no client has used it for sshIdleTimeout
+func (pool *sshConnectionPool) release(conn *sshConnection) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+	conn.refs--
+	if conn.refs > 0 || conn.err != nil {
+		return
+	}
+	if pool.conns[conn.key] != conn {
+		go conn.close()
+		return
+	}
+	conn.idleTimer = time.AfterFunc(sshIdleTimeout, func() {
+		pool.mutex.Lock()
+		idle := conn.refs == 0 && pool.conns[conn.key] == conn
+		if idle {
+			delete(pool.conns, conn.key)
+		}
+		pool.mutex.Unlock()
+		if idle {
+			conn.close()
+		}
+	})
+}
+
+// remove removes the connection from the pool, and closes it if no client uses it
+func (pool *sshConnectionPool) remove(conn *sshConnection) {
+	pool.mutex.Lock()
+	if pool.conns[conn.key] == conn {
+		delete(pool.conns, conn.key)
+	}
+	unused := conn.refs == 0
+	pool.mutex.Unlock()
+	if unused {
+		conn.close()
+	}
+}
+
+// CloseSSHConnections closes the connections of the pool, which is done once the command ends.
+// The clients still connected keep their connection until they disconnect
+func CloseSSHConnections() {
+	pool := sshPool
+	pool.mutex.Lock()
+	unused := []*sshConnection{}
+	for key, conn := range pool.conns {
+		delete(pool.conns, key)
+		if conn.refs == 0 {
+			if conn.idleTimer != nil {
+				conn.idleTimer.Stop()
+			}
+			unused = append(unused, conn)
+		}
+	}
+	pool.mutex.Unlock()
+	for _, conn := range unused {
+		conn.close()
+	}
+}
@@ -0,0 +1,201 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+// PrivilegeEscalation is how the commands which start with sudo are run as root
+type PrivilegeEscalation string
+
+const (
+	// SudoEscalation runs the commands with sudo, the default
+	SudoEscalation PrivilegeEscalation = "sudo"
+	// DoasEscalation runs the commands with doas, for hosts without sudo
+	DoasEscalation PrivilegeEscalation = "doas"
+	// RootEscalation runs the commands as they are, for hosts logged in to as root
+	RootEscalation PrivilegeEscalation = "root"
+)
+
+// SSHPrivilege holds how a SecureShellClient escalates the privileges of its commands
+type SSHPrivilege struct {
+	Escalation PrivilegeEscalation
+	// PasswordAuth answers the password prompts of sudo and doas through a PTY.
+	// The password is read from PasswordEnv, or prompted for if it is empty
+	PasswordAuth bool
+	PasswordEnv  string
+}
+
+// Validate checks the escalation is supported
+func (privilege SSHPrivilege) Validate() error {
+	switch privilege.Escalation {
+	case "", SudoEscalation, DoasEscalation, RootEscalation:
+		return nil
+	}
+	return NewInputError(fmt.Sprintf("Unsupported privilege escalation %s, expected one of %s, %s, %s", privilege.Escalation, SudoEscalation, DoasEscalation, RootEscalation))
+}
+
+func (privilege SSHPrivilege) hasPassword() bool {
+	return privilege.Escalation != RootEscalation && (privilege.PasswordAuth || privilege.PasswordEnv != "")
+}
+
+// Prompt of the sudo commands run with a password, which cannot be mistaken for the output of a command
+const sudoPrompt = "[iofogctl] sudo password: "
+
+var (
+	// Matches sudo at the start of a command. The -S flag is dropped, the password is read from the PTY
+	sudoCommandRegex = regexp.MustCompile(`(^|&&|\|\||[;|(\n]|\$\()(\s*)sudo(\s+-S)?\s+`)
+	// Matches the prompt given to sudo and the password prompt of doas. Other prompts, which may be printed by the command
+	// itself, are never answered with the password
+	passwordPromptRegex = regexp.MustCompile(`(\[iofogctl\] sudo password|doas \([^\n)]*\) password): ?`)
+	// Matches the errors of sudo and doas when the password is wrong
+	wrongPasswordRegex = regexp.MustCompile(`incorrect password|Sorry, try again|Authentication failed|Authorization failed`)
+)
+
+// Directory of the sudo shim of the hosts which escalate with doas or as root, relative to the home directory
+const sudoShimDir = ".iofogctl/bin"
+
+// The sudo shim strips the options of sudo and runs the command with doas, or as it is once root.
+// The scripts copied to the hosts call sudo themselves, so the shim is first in the PATH of the commands
+const sudoShim = `#!/bin/sh
+while [ $# -gt 0 ]; do
+	case "$1" in
+		-p|-u) shift 2 ;;
+		-*) shift ;;
+		*) break ;;
+	esac
+done
+if [ "$(id -u)" = "0" ]; then
+	exec "$@"
+fi
+exec doas env PATH="$PATH" "$@"
+`
+
+// SetPrivilege sets how the commands which start with sudo are run, see Run
+func (cl *SecureShellClient) SetPrivilege(privilege SSHPrivilege) {
+	SSHVerbose(fmt.Sprintf("Escalating privileges with %s", privilege.getEscalation()))
+	cl.privilege = privilege
+}
+
+func (privilege SSHPrivilege) getEscalation() PrivilegeEscalation {
+	if privilege.Escalation == "" {
+		return SudoEscalation
+	}
+	return privilege.Escalation
+}
+
+// escalate prepares the sudo commands of cmd for the escalation of the client.
+// With doas or as root, sudo is replaced by the shim, which is installed once and put first in the PATH
+func (cl *SecureShellClient) escalate(cmd string) string {
+	if cl.privilege.getEscalation() == SudoEscalation {
+		if cl.privilege.hasPassword() {
+			cmd = sudoCommandRegex.ReplaceAllString(cmd, fmt.Sprintf(`${1}${2}sudo -p '%s' `, sudoPrompt))
+		}
+		return cmd
+	}
+	shim := fmt.Sprintf(`[ -x "$HOME/%[1]s/sudo" ] || { mkdir -p "$HOME/%[1]s" && printf '%%s' %[2]s > "$HOME/%[1]s/sudo" && chmod 0755 "$HOME/%[1]s/sudo"; } && PATH="$HOME/%[1]s:$PATH" && export PATH && `,
+		sudoShimDir, shellQuote(sudoShim))
+	return shim + cmd
+}
+
+// RunPrivileged runs the command as root, e.g. once it failed because of the permissions of the user
+func (cl *SecureShellClient) RunPrivileged(cmd string) (bytes.Buffer, error) {
+	return cl.Run("sudo sh -c " + shellQuote(cmd))
+}
+
+// shellQuote quotes the argument for sh
+func shellQuote(arg string) string {
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
+
+// preparePrivilege escalates the command, and answers the password prompts of its output when a password is set.
+// The prompts are only printed to a terminal, so a PTY is requested, which merges stderr into stdout
+func (cl *SecureShellClient) preparePrivilege(session *ssh.Session, cmd string, stdout *bytes.Buffer) (string, error) {
+	cmd = cl.escalate(cmd)
+	if !cl.privilege.hasPassword() {
+		return cmd, nil
+	}
+	password, err := cl.getSecret(cl.privilege.PasswordEnv, cl.getPrivilegeSecretKey(), fmt.Sprintf("Enter %s password for %s@%s: ", cl.privilege.getEscalation(), cl.user, cl.host))
+	if err != nil {
+		return "", err
+	}
+	modes := ssh.TerminalModes{
+		ssh.ECHO:          0,
+		ssh.TTY_OP_ISPEED: 14400,
+		ssh.TTY_OP_OSPEED: 14400,
+	}
+	if err := session.RequestPty("xterm", 40, 200, modes); err != nil {
+		return "", err
+	}
+	stdin, err := session.StdinPipe()
+	if err != nil {
+		return "", err
+	}
+	session.Stdout = &promptResponder{output: stdout, stdin: stdin, password: password}
+	return cmd, nil
+}
+
+// finishPrivilege removes the password prompts and the carriage returns of the PTY from the output,
+// and forgets the password if it was wrong
+func (cl *SecureShellClient) finishPrivilege(stdout *bytes.Buffer, err error) {
+	if !cl.privilege.hasPassword() {
+		return
+	}
+	output := strings.ReplaceAll(stdout.String(), "\r\n", "\n")
+	if err != nil && wrongPasswordRegex.MatchString(output) {
+		forgetSecret(cl.getPrivilegeSecretKey())
+	}
+	output = passwordPromptRegex.ReplaceAllString(output, "")
+	stdout.Reset()
+	stdout.WriteString(output)
+}
+
+func (cl *SecureShellClient) getPrivilegeSecretKey() string {
+	return fmt.Sprintf("privilege:%s@%s", cl.user, cl.host)
+}
+
+// promptResponder writes the password to stdin each time the output ends with a password prompt
+type promptResponder struct {
+	output   *bytes.Buffer
+	stdin    io.Writer
+	password string
+	// End of the output, which the prompt is looked for in
+	tail []byte
+}
+
+// Length of the end of the output kept to find the prompts split across writes
+const promptTailLen = 256
+
+func (responder *promptResponder) Write(data []byte) (int, error) {
+	responder.output.Write(data)
+	responder.tail = append(responder.tail, data...)
+	if len(responder.tail) > promptTailLen {
+		responder.tail = responder.tail[len(responder.tail)-promptTailLen:]
+	}
+	locs := passwordPromptRegex.FindAllIndex(responder.tail, -1)
+	if len(locs) > 0 && len(bytes.TrimRight(responder.tail[locs[len(locs)-1][1]:], " ")) == 0 {
+		responder.tail = nil
+		if _, err := io.WriteString(responder.stdin, responder.password+"\n"); err != nil {
+			return 0, err
+		}
+	}
+	return len(data), nil
+}
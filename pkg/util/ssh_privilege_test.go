@@ -0,0 +1,106 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/ssh"
+)
+
+func TestSSHPrivilegeEscalate(t *testing.T) {
+	cl := &SecureShellClient{}
+	cmd := "sudo mkdir -p /etc/iofog && sudo -S chmod 0777 /etc/iofog; echo sudo"
+	if escalated := cl.escalate(cmd); escalated != cmd {
+		t.Errorf("Expected passwordless sudo to be left as is, got %s", escalated)
+	}
+
+	cl.SetPrivilege(SSHPrivilege{PasswordEnv: "SUDO_PASSWORD"})
+	expected := "sudo -p '[iofogctl] sudo password: ' mkdir -p /etc/iofog && sudo -p '[iofogctl] sudo password: ' chmod 0777 /etc/iofog; echo sudo"
+	if escalated := cl.escalate(cmd); escalated != expected {
+		t.Errorf("Expected %s, got %s", expected, escalated)
+	}
+
+	for _, escalation := range []PrivilegeEscalation{DoasEscalation, RootEscalation} {
+		cl.SetPrivilege(SSHPrivilege{Escalation: escalation})
+		escalated := cl.escalate(cmd)
+		if !strings.HasSuffix(escalated, `PATH="$HOME/.iofogctl/bin:$PATH" && export PATH && `+cmd) {
+			t.Errorf("Expected %s to run sudo with the shim, got %s", escalation, escalated)
+		}
+	}
+
+	if err := (SSHPrivilege{Escalation: "su"}).Validate(); err == nil {
+		t.Error("Expected su escalation to be rejected")
+	}
+}
+
+func TestSSHSudoPassword(t *testing.T) {
+	server := newTestServer(t, &ssh.ServerConfig{NoClientAuth: true})
+	t.Setenv("SSH_PASSWORD", "")
+	t.Setenv("SUDO_PASSWORD", "secret")
+	cl := server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+	cl.SetPrivilege(SSHPrivilege{PasswordEnv: "SUDO_PASSWORD"})
+	if err := cl.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer CloseSSHConnections()
+	defer cl.Disconnect()
+
+	stdout, err := cl.Run("sudo whoami")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stdout.String() != "\nroot\n" {
+		t.Errorf("Expected the output of sudo without prompt, got %q", stdout.String())
+	}
+	if stdout, err = cl.Run("whoami"); err != nil || stdout.String() != "whoami" {
+		t.Errorf("Expected commands without sudo to run as they are, got %q, %v", stdout.String(), err)
+	}
+
+	t.Setenv("SUDO_PASSWORD", "wrong")
+	if _, err := cl.Run("sudo whoami"); err == nil || !strings.Contains(err.Error(), "incorrect password") {
+		t.Errorf("Expected a wrong sudo password to be rejected, got %v", err)
+	}
+}
+
+func TestSSHPromptResponder(t *testing.T) {
+	stdin := &bytes.Buffer{}
+	responder := &promptResponder{output: &bytes.Buffer{}, stdin: stdin, password: "secret"}
+
+	// Prompts of the command itself are not answered
+	for _, prompt := range []string{"Password: ", "[sudo] password for iofog: ", "Enter password: "} {
+		if _, err := responder.Write([]byte(prompt)); err != nil {
+			t.Fatal(err)
+		}
+		if stdin.Len() != 0 {
+			t.Fatalf("Expected prompt %q not to be answered, got %q", prompt, stdin.String())
+		}
+	}
+
+	// The prompts of sudo and doas are answered, even when split across writes
+	for _, prompt := range []string{sudoPrompt, "doas (iofog@agent) password: "} {
+		stdin.Reset()
+		if _, err := responder.Write([]byte("\n" + prompt[:5])); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := responder.Write([]byte(prompt[5:])); err != nil {
+			t.Fatal(err)
+		}
+		if stdin.String() != "secret\n" {
+			t.Errorf("Expected prompt %q to be answered, got %q", prompt, stdin.String())
+		}
+	}
+}
@@ -0,0 +1,494 @@
+/*
+ *  *******************************************************************************
+ *  * Copyright (c) 2020 Edgeworx, Inc.
+ *  *
+ *  * This program and the accompanying materials are made available under the
+ *  * terms of the Eclipse Public License v. 2.0 which is available at
+ *  * http://www.eclipse.org/legal/epl-2.0
+ *  *
+ *  * SPDX-License-Identifier: EPL-2.0
+ *  *******************************************************************************
+ *
+ */
+
+package util
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/binary"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+// testServer is an SSH server which runs no command, it replies to each command with the command itself.
+// It prompts for the password of the sudo -p commands, forwards TCP connections like a jump host, and serves the local files through SFTP
+type testServer struct {
+	host      string
+	port      int
+	hostKey   ssh.PublicKey
+	forwarded int32
+	// Number of SSH connections established
+	connections int32
+}
+
+func newTestServer(t *testing.T, config *ssh.ServerConfig) *testServer {
+	// Ignore the OpenSSH client configuration of the user
+	setSSHConfig(t, "")
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.AddHostKey(signer)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { listener.Close() })
+	addr := listener.Addr().(*net.TCPAddr)
+	server := &testServer{host: addr.IP.String(), port: addr.Port, hostKey: signer.PublicKey()}
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn, config)
+		}
+	}()
+	return server
+}
+
+func (server *testServer) serve(conn net.Conn, config *ssh.ServerConfig) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, config)
+	if err != nil {
+		conn.Close()
+		return
+	}
+	atomic.AddInt32(&server.connections, 1)
+	go ssh.DiscardRequests(reqs)
+	for newChan := range chans {
+		if newChan.ChannelType() == "direct-tcpip" {
+			server.forward(newChan)
+			continue
+		}
+		if newChan.ChannelType() != "session" {
+			_ = newChan.Reject(ssh.UnknownChannelType, "unsupported channel")
+			continue
+		}
+		channel, requests, err := newChan.Accept()
+		if err != nil {
+			continue
+		}
+		go func() {
+			defer channel.Close()
+			pty := false
+			for req := range requests {
+				if req.Type == "pty-req" {
+					pty = true
+					_ = req.Reply(true, nil)
+					continue
+				}
+				if req.Type == "subsystem" && string(req.Payload[4:]) == "sftp" {
+					_ = req.Reply(true, nil)
+					server, err := sftp.NewServer(channel)
+					if err == nil {
+						_ = server.Serve()
+					}
+					return
+				}
+				if req.Type != "exec" {
+					_ = req.Reply(false, nil)
+					continue
+				}
+				_ = req.Reply(true, nil)
+				cmdLen := binary.BigEndian.Uint32(req.Payload)
+				cmd := string(req.Payload[4 : 4+cmdLen])
+				status := uint32(0)
+				if strings.HasPrefix(cmd, "sudo -p '") {
+					status = sudo(channel, cmd, pty)
+				} else {
+					_, _ = channel.Write([]byte(cmd))
+				}
+				_, _ = channel.SendRequest("exit-status", false, ssh.Marshal(struct{ Status uint32 }{status}))
+				return
+			}
+		}()
+	}
+}
+
+// sudo prompts for the password of the sudo command and checks it is secret
+func sudo(channel ssh.Channel, cmd string, pty bool) uint32 {
+	if !pty {
+		_, _ = channel.Stderr().Write([]byte("sudo: a terminal is required to read the password\n"))
+		return 1
+	}
+	prompt := strings.SplitN(cmd, "'", 3)[1]
+	_, _ = channel.Write([]byte(prompt))
+	password := []byte{}
+	char := make([]byte, 1)
+	for {
+		if _, err := channel.Read(char); err != nil || char[0] == '\n' {
+			break
+		}
+		password = append(password, char[0])
+	}
+	if string(password) != "secret" {
+		_, _ = channel.Write([]byte("\r\nSorry, try again.\r\nsudo: 1 incorrect password attempt\r\n"))
+		return 1
+	}
+	_, _ = channel.Write([]byte("\r\nroot\r\n"))
+	return 0
+}
+
+func (server *testServer) forward(newChan ssh.NewChannel) {
+	var target struct {
+		Host       string
+		Port       uint32
+		OriginHost string
+		OriginPort uint32
+	}
+	if err := ssh.Unmarshal(newChan.ExtraData(), &target); err != nil {
+		_ = newChan.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	conn, err := net.Dial("tcp", net.JoinHostPort(target.Host, strconv.Itoa(int(target.Port))))
+	if err != nil {
+		_ = newChan.Reject(ssh.ConnectionFailed, err.Error())
+		return
+	}
+	channel, requests, err := newChan.Accept()
+	if err != nil {
+		conn.Close()
+		return
+	}
+	atomic.AddInt32(&server.forwarded, 1)
+	go ssh.DiscardRequests(requests)
+	go func() {
+		_, _ = io.Copy(conn, channel)
+		conn.Close()
+	}()
+	go func() {
+		_, _ = io.Copy(channel, conn)
+		channel.Close()
+	}()
+}
+
+func (server *testServer) newClient(t *testing.T, user string, auth SSHAuth) *SecureShellClient {
+	cl, err := NewSecureShellClient(user, server.host, auth)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cl.SetPort(server.port)
+	cl.SetHostKey(ssh.FingerprintSHA256(server.hostKey))
+	return cl
+}
+
+// runEcho runs a command, then closes the connections of the pool so the next client authenticates again
+func runEcho(t *testing.T, cl *SecureShellClient) {
+	if err := cl.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer CloseSSHConnections()
+	defer cl.Disconnect()
+	stdout, err := cl.Run("echo ok")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stdout.String() != "echo ok" {
+		t.Errorf("Expected output echo ok, got %s", stdout.String())
+	}
+}
+
+func TestSSHAuthIsEmpty(t *testing.T) {
+	if !(SSHAuth{KeyPassphraseEnv: "PASSPHRASE"}).IsEmpty() {
+		t.Error("Expected a passphrase without key file to be no authentication method")
+	}
+	for _, auth := range []SSHAuth{{KeyFile: "id_rsa"}, {Agent: true}, {PasswordAuth: true}, {PasswordEnv: "PASSWORD"}} {
+		if auth.IsEmpty() {
+			t.Errorf("Expected %v to be an authentication method", auth)
+		}
+	}
+	setSSHConfig(t, "")
+	t.Setenv("SSH_AUTH_SOCK", "")
+	cl, err := NewSecureShellClient("user", "host", SSHAuth{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := cl.Connect(); err == nil || !strings.Contains(err.Error(), "No SSH authentication method for user@host") {
+		t.Errorf("Expected a client without authentication method to be rejected, got %v", err)
+	}
+}
+
+func TestSSHPasswordAuth(t *testing.T) {
+	server := newTestServer(t, &ssh.ServerConfig{
+		PasswordCallback: func(conn ssh.ConnMetadata, password []byte) (*ssh.Permissions, error) {
+			if conn.User() == "iofog" && string(password) == "secret" {
+				return nil, nil
+			}
+			return nil, ssh.ErrNoAuth
+		},
+	})
+	t.Setenv("SSH_PASSWORD", "secret")
+	runEcho(t, server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"}))
+
+	t.Setenv("SSH_PASSWORD", "wrong")
+	cl := server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+	if err := cl.Connect(); err == nil {
+		cl.Disconnect()
+		t.Error("Expected a wrong password to be rejected")
+	}
+}
+
+func TestSSHEncryptedKeyAuth(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	//nolint:staticcheck // Deprecated, but still a supported encryption of SSH keys
+	block, err := x509.EncryptPEMBlock(rand.Reader, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(key), []byte("passphrase"), x509.PEMCipherAES256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyFile := filepath.Join(t.TempDir(), "id_rsa")
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(block), 0600); err != nil {
+		t.Fatal(err)
+	}
+	pub, err := ssh.NewPublicKey(&key.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newTestServer(t, &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) == string(pub.Marshal()) {
+				return nil, nil
+			}
+			return nil, ssh.ErrNoAuth
+		},
+	})
+
+	t.Setenv("SSH_KEY_PASSPHRASE", "passphrase")
+	runEcho(t, server.newClient(t, "iofog", SSHAuth{KeyFile: keyFile, KeyPassphraseEnv: "SSH_KEY_PASSPHRASE"}))
+
+	t.Setenv("SSH_KEY_PASSPHRASE", "wrong")
+	cl := server.newClient(t, "iofog", SSHAuth{KeyFile: keyFile, KeyPassphraseEnv: "SSH_KEY_PASSPHRASE"})
+	if err := cl.Connect(); err == nil || !strings.Contains(err.Error(), "Could not decrypt private key") {
+		t.Errorf("Expected a wrong passphrase to be rejected, got %v", err)
+	}
+
+	cl = server.newClient(t, "iofog", SSHAuth{KeyFile: keyFile, KeyPassphraseEnv: "SSH_UNSET_PASSPHRASE"})
+	if err := cl.Connect(); err == nil || !strings.Contains(err.Error(), "SSH_UNSET_PASSPHRASE is not set") {
+		t.Errorf("Expected a missing passphrase to be rejected, got %v", err)
+	}
+}
+
+func TestSSHAgentAuth(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyring := agent.NewKeyring()
+	if err := keyring.Add(agent.AddedKey{PrivateKey: priv}); err != nil {
+		t.Fatal(err)
+	}
+	socket := filepath.Join(t.TempDir(), "agent.sock")
+	listener, err := net.Listen("unix", socket)
+	if err != nil {
+		t.Skip("Unix sockets are not supported: " + err.Error())
+	}
+	defer listener.Close()
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go func() {
+				_ = agent.ServeAgent(keyring, conn)
+				conn.Close()
+			}()
+		}
+	}()
+	signer, err := ssh.NewSignerFromKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	server := newTestServer(t, &ssh.ServerConfig{
+		PublicKeyCallback: func(conn ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if string(key.Marshal()) == string(signer.PublicKey().Marshal()) {
+				return nil, nil
+			}
+			return nil, ssh.ErrNoAuth
+		},
+	})
+
+	t.Setenv("SSH_AUTH_SOCK", socket)
+	runEcho(t, server.newClient(t, "iofog", SSHAuth{Agent: true}))
+
+	t.Setenv("SSH_AUTH_SOCK", "")
+	cl := server.newClient(t, "iofog", SSHAuth{Agent: true})
+	if err := cl.Connect(); err == nil || !strings.Contains(err.Error(), "SSH_AUTH_SOCK is not set") {
+		t.Errorf("Expected the ssh-agent to be missing, got %v", err)
+	}
+}
+
+func TestSSHJumpHosts(t *testing.T) {
+	newPasswordServer := func(user, password string) *testServer {
+		return newTestServer(t, &ssh.ServerConfig{
+			PasswordCallback: func(conn ssh.ConnMetadata, pass []byte) (*ssh.Permissions, error) {
+				if conn.User() == user && string(pass) == password {
+					return nil, nil
+				}
+				return nil, ssh.ErrNoAuth
+			},
+		})
+	}
+	bastion := newPasswordServer("bastion", "bastion-secret")
+	gateway := newPasswordServer("gateway", "gateway-secret")
+	target := newPasswordServer("iofog", "secret")
+	t.Setenv("BASTION_PASSWORD", "bastion-secret")
+	t.Setenv("GATEWAY_PASSWORD", "gateway-secret")
+	t.Setenv("SSH_PASSWORD", "secret")
+
+	cl := target.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+	cl.SetJumpHosts([]SSHJumpHost{
+		{User: "bastion", Host: bastion.host, Port: bastion.port, Auth: SSHAuth{PasswordEnv: "BASTION_PASSWORD"}, HostKey: ssh.FingerprintSHA256(bastion.hostKey)},
+		{User: "gateway", Host: gateway.host, Port: gateway.port, Auth: SSHAuth{PasswordEnv: "GATEWAY_PASSWORD"}, HostKey: ssh.FingerprintSHA256(gateway.hostKey)},
+	})
+	runEcho(t, cl)
+	if atomic.LoadInt32(&bastion.forwarded) != 1 || atomic.LoadInt32(&gateway.forwarded) != 1 {
+		t.Errorf("Expected the connection to go through both jump hosts, got %d and %d forwards", bastion.forwarded, gateway.forwarded)
+	}
+
+	cl = target.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+	cl.SetJumpHosts([]SSHJumpHost{
+		{User: "bastion", Host: bastion.host, Port: bastion.port, Auth: SSHAuth{PasswordEnv: "GATEWAY_PASSWORD"}, HostKey: ssh.FingerprintSHA256(bastion.hostKey)},
+	})
+	if err := cl.Connect(); err == nil || !strings.Contains(err.Error(), "Could not connect to jump host bastion@") {
+		t.Errorf("Expected the jump host to reject the connection, got %v", err)
+	}
+}
+
+func TestSSHConnectionPool(t *testing.T) {
+	server := newTestServer(t, &ssh.ServerConfig{NoClientAuth: true})
+	t.Setenv("SSH_PASSWORD", "unused")
+	defer CloseSSHConnections()
+
+	// Clients of the same host share a connection, and run more commands at once than a connection has sessions
+	wg := sync.WaitGroup{}
+	errs := make(chan error, 3*maxSSHSessions)
+	for idx := 0; idx < 3*maxSSHSessions; idx++ {
+		wg.Add(1)
+		go func(idx int) {
+			defer wg.Done()
+			cl := server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+			if err := cl.Connect(); err != nil {
+				errs <- err
+				return
+			}
+			defer cl.Disconnect()
+			cmd := fmt.Sprintf("echo %d", idx)
+			stdout, err := cl.Run(cmd)
+			if err == nil && stdout.String() != cmd {
+				err = fmt.Errorf("Expected output %s, got %s", cmd, stdout.String())
+			}
+			errs <- err
+		}(idx)
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if connections := atomic.LoadInt32(&server.connections); connections != 1 {
+		t.Errorf("Expected the clients to share 1 connection, got %d", connections)
+	}
+
+	// Idle connections are closed
+	previousTimeout := sshIdleTimeout
+	sshIdleTimeout = time.Millisecond
+	defer func() { sshIdleTimeout = previousTimeout }()
+	runEcho(t, server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"}))
+	if connections := atomic.LoadInt32(&server.connections); connections != 1 {
+		t.Errorf("Expected the idle connection to be reused, got %d connections", connections)
+	}
+	cl := server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+	if err := cl.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	cl.Disconnect()
+	time.Sleep(100 * time.Millisecond)
+	sshPool.mutex.Lock()
+	pooled := len(sshPool.conns)
+	sshPool.mutex.Unlock()
+	if pooled != 0 || atomic.LoadInt32(&server.connections) != 2 {
+		t.Errorf("Expected the idle connection to be closed, got %d pooled connections", pooled)
+	}
+}
+
+func TestSSHConnectionPoolKey(t *testing.T) {
+	server := newTestServer(t, &ssh.ServerConfig{NoClientAuth: true})
+	bastion := newTestServer(t, &ssh.ServerConfig{NoClientAuth: true})
+	t.Setenv("SSH_PASSWORD", "unused")
+	t.Setenv("OTHER_PASSWORD", "other")
+	defer CloseSSHConnections()
+
+	cl := server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+	if err := cl.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer cl.Disconnect()
+
+	// A client pinning another host key verifies it instead of reusing the connection
+	other := server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+	other.SetHostKey(ssh.FingerprintSHA256(bastion.hostKey))
+	if err := other.Connect(); err == nil || !strings.Contains(err.Error(), "does not match its pinned key") {
+		t.Errorf("Expected the host key not to match, got %v", err)
+	}
+
+	// A client tunnelled through a jump host opens its own connection
+	jumped := server.newClient(t, "iofog", SSHAuth{PasswordEnv: "SSH_PASSWORD"})
+	jumped.SetJumpHosts([]SSHJumpHost{
+		{User: "bastion", Host: bastion.host, Port: bastion.port, Auth: SSHAuth{PasswordEnv: "SSH_PASSWORD"}, HostKey: ssh.FingerprintSHA256(bastion.hostKey)},
+	})
+	if err := jumped.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer jumped.Disconnect()
+	if atomic.LoadInt32(&bastion.forwarded) != 1 || atomic.LoadInt32(&server.connections) != 2 {
+		t.Errorf("Expected a connection through the jump host, got %d forwards and %d connections", bastion.forwarded, server.connections)
+	}
+
+	// So does a client with another password
+	other = server.newClient(t, "iofog", SSHAuth{PasswordEnv: "OTHER_PASSWORD"})
+	if err := other.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	defer other.Disconnect()
+	if connections := atomic.LoadInt32(&server.connections); connections != 3 {
+		t.Errorf("Expected a connection per password, got %d connections", connections)
+	}
+}
@@ -14,10 +14,23 @@
 package util
 
 import (
+	"context"
 	"fmt"
 	"time"
 )
 
+// Sleep waits for the duration, returning early with the error of the context if it is done first
+func Sleep(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
 func NowUTC() string {
 	return time.Now().Format(time.UnixDate)
 }